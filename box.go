@@ -0,0 +1,191 @@
+package main
+
+// PC boxes organize catch records into named groups. A Pokemon is either in
+// the party, in one box, or in neither.
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const boxCapacity = 30
+
+type pcBox struct {
+	Name    string `json:"name"`
+	Members []int  `json:"members"`
+}
+
+func commandBox(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return printBoxes(cfg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		if len(args) > 1 {
+			return printBox(cfg, strings.Join(args[1:], " "))
+		}
+		return printBoxes(cfg)
+	case "create":
+		if len(args) < 2 {
+			fmt.Printf("Please provide a name for the new box\n\n")
+			return nil
+		}
+		return boxCreate(cfg, strings.Join(args[1:], " "))
+	case "move":
+		if len(args) < 3 {
+			fmt.Printf("Please provide the Pokemon to move and the box to move it to\n\n")
+			return nil
+		}
+		return boxMove(cfg, args[1], strings.Join(args[2:], " "))
+	case "delete":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the name of the box to delete\n\n")
+			return nil
+		}
+		return boxDelete(cfg, strings.Join(args[1:], " "))
+	}
+	return fmt.Errorf("Unknown box command '%s', expected list, create, move or delete", args[0])
+}
+
+func (cfg *config) findBox(name string) (*pcBox, bool) {
+	for i := range cfg.boxes {
+		if strings.EqualFold(cfg.boxes[i].Name, name) {
+			return &cfg.boxes[i], true
+		}
+	}
+	return nil, false
+}
+
+func (cfg *config) boxOf(id int) (*pcBox, bool) {
+	for i := range cfg.boxes {
+		if slices.Contains(cfg.boxes[i].Members, id) {
+			return &cfg.boxes[i], true
+		}
+	}
+	return nil, false
+}
+
+func (cfg *config) removeFromBoxes(id int) {
+	for i := range cfg.boxes {
+		cfg.boxes[i].Members = slices.DeleteFunc(cfg.boxes[i].Members, func(member int) bool {
+			return member == id
+		})
+	}
+}
+
+func boxCreate(cfg *config, name string) error {
+	if _, exists := cfg.findBox(name); exists {
+		return fmt.Errorf("A box called '%s' already exists", name)
+	}
+
+	cfg.boxes = append(cfg.boxes, pcBox{Name: name, Members: []int{}})
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Created box '%s' (holds up to %d Pokemon)\n\n", name, boxCapacity)
+	return nil
+}
+
+func boxMove(cfg *config, ref, boxName string) error {
+	box, ok := cfg.findBox(boxName)
+	if !ok {
+		return fmt.Errorf("There is no box called '%s'", boxName)
+	}
+	record, ok := cfg.resolveCatch(ref, func(r catchRecord) bool {
+		return !slices.Contains(box.Members, r.ID)
+	})
+	if !ok {
+		return fmt.Errorf("You haven't caught '%s' (or all of them are already in box '%s')", ref, box.Name)
+	}
+	if len(box.Members) >= boxCapacity {
+		return fmt.Errorf("Box '%s' is full (%d/%d)", box.Name, len(box.Members), boxCapacity)
+	}
+
+	if slices.Contains(cfg.party, record.ID) {
+		cfg.removeFromParty(record.ID)
+		fmt.Printf("#%d %s left your party\n", record.ID, record.displayName())
+	}
+	cfg.removeFromBoxes(record.ID)
+	box.Members = append(box.Members, record.ID)
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s was moved to box '%s'\n\n", record.ID, record.displayName(), box.Name)
+	return nil
+}
+
+func boxDelete(cfg *config, name string) error {
+	box, ok := cfg.findBox(name)
+	if !ok {
+		return fmt.Errorf("There is no box called '%s'", name)
+	}
+	if len(box.Members) > 0 {
+		return fmt.Errorf("Box '%s' still holds %d Pokemon, move them out first", box.Name, len(box.Members))
+	}
+
+	deleted := box.Name
+	cfg.boxes = slices.DeleteFunc(cfg.boxes, func(b pcBox) bool {
+		return b.Name == deleted
+	})
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Deleted box '%s'\n\n", deleted)
+	return nil
+}
+
+func printBoxes(cfg *config) error {
+	if len(cfg.boxes) == 0 {
+		fmt.Printf("You don't have any boxes yet. Create one with 'box create <name>'\n\n")
+		return nil
+	}
+
+	rows := [][]string{headings(cfg, []string{"BOX", "POKEMON"})}
+	boxed := 0
+	for _, box := range cfg.boxes {
+		rows = append(rows, []string{box.Name, fmt.Sprintf("%d/%d", len(box.Members), boxCapacity)})
+		boxed += len(box.Members)
+	}
+	printTable(rows, 1)
+
+	loose := 0
+	for _, record := range cfg.catches {
+		if _, inBox := cfg.boxOf(record.ID); !inBox && !slices.Contains(cfg.party, record.ID) {
+			loose++
+		}
+	}
+	fmt.Printf("\nIn your party: %d, in boxes: %d, elsewhere: %d\n\n", len(cfg.party), boxed, loose)
+	return nil
+}
+
+func printBox(cfg *config, name string) error {
+	box, ok := cfg.findBox(name)
+	if !ok {
+		return fmt.Errorf("There is no box called '%s'", name)
+	}
+
+	fmt.Printf("Box '%s' (%d/%d):\n\n", box.Name, len(box.Members), boxCapacity)
+	if len(box.Members) == 0 {
+		fmt.Printf("This box is empty\n\n")
+		return nil
+	}
+
+	rows := [][]string{headings(cfg, []string{"ID", "NAME", "DEX", "LV", "TYPES"})}
+	for _, id := range box.Members {
+		record, ok := cfg.findCatch(strconv.Itoa(id))
+		if !ok {
+			continue
+		}
+		pokemon := cfg.caughtPokemon[record.Species]
+		rows = append(rows, []string{
+			fmt.Sprintf("#%d", record.ID), record.displayName(), fmt.Sprint(pokemon.ID),
+			fmt.Sprint(record.Level), cfg.out.typeNames(pokemon.TypeNames()),
+		})
+	}
+	printTable(rows, 2, 3)
+	fmt.Println()
+	return nil
+}
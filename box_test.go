@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestBoxes(t *testing.T) {
+	cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	pikachu := cfg.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+	cfg.recordCatch(pokeapi.Pokemon{ID: 1, Name: "bulbasaur"})
+
+	if err := commandBox(cfg, "create", "Electric"); err != nil {
+		t.Fatalf("Unexpected error creating box: %v", err)
+	}
+	if err := commandBox(cfg, "create", "electric"); err == nil {
+		t.Error("Expected duplicate box names to be rejected")
+	}
+	if err := commandBox(cfg, "move", "pikachu", "Grass"); err == nil {
+		t.Error("Expected moving to a missing box to fail")
+	}
+
+	if err := commandParty(cfg, "add", "pikachu"); err != nil {
+		t.Fatalf("Unexpected error adding to party: %v", err)
+	}
+	if err := commandBox(cfg, "move", "pikachu", "electric"); err != nil {
+		t.Fatalf("Unexpected error moving to box: %v", err)
+	}
+	if len(cfg.party) != 0 {
+		t.Errorf("Expected boxed Pokemon to leave the party, got %v", cfg.party)
+	}
+	if box, ok := cfg.boxOf(pikachu.ID); !ok || box.Name != "Electric" {
+		t.Errorf("Expected pikachu in box Electric, got %v", box)
+	}
+
+	if err := commandBox(cfg, "delete", "Electric"); err == nil {
+		t.Error("Expected deleting a non-empty box to fail")
+	}
+
+	if err := commandParty(cfg, "add", "pikachu"); err != nil {
+		t.Fatalf("Unexpected error adding to party: %v", err)
+	}
+	if _, ok := cfg.boxOf(pikachu.ID); ok {
+		t.Error("Expected pikachu to leave its box when joining the party")
+	}
+	if err := commandBox(cfg, "delete", "Electric"); err != nil {
+		t.Errorf("Unexpected error deleting empty box: %v", err)
+	}
+}
+
+func TestBoxCapacity(t *testing.T) {
+	cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	for range boxCapacity + 1 {
+		cfg.recordCatch(pokeapi.Pokemon{ID: 129, Name: "magikarp"})
+	}
+	if err := commandBox(cfg, "create", "fish"); err != nil {
+		t.Fatalf("Unexpected error creating box: %v", err)
+	}
+
+	for range boxCapacity {
+		if err := commandBox(cfg, "move", "magikarp", "fish"); err != nil {
+			t.Fatalf("Unexpected error moving to box: %v", err)
+		}
+	}
+	if err := commandBox(cfg, "move", "magikarp", "fish"); err == nil {
+		t.Error("Expected a full box to reject more Pokemon")
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+// Every catch attempt is appended to a JSON Lines file in the profile
+// directory. The log is never rewritten, so releasing or importing Pokemon
+// doesn't change the history the stats command reports on.
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const mostEscapedShown = 5
+
+type catchAttempt struct {
+	Time           time.Time `json:"time"`
+	Pokemon        string    `json:"pokemon"`
+	Roll           int       `json:"roll"`
+	BaseExperience int       `json:"base_experience"`
+	Caught         bool      `json:"caught"`
+	Area           string    `json:"area,omitempty"`
+}
+
+func profileCatchLogPath(profilesDir, name string) string {
+	return filepath.Join(profilesDir, name, "catches.jsonl")
+}
+
+// Like save, a config without a log path (as in tests) doesn't write anything
+func (cfg *config) logCatchAttempt(attempt catchAttempt) error {
+	if cfg.catchLogPath == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(attempt)
+	if err != nil {
+		return fmt.Errorf("Error encoding catch attempt: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.catchLogPath), 0o755); err != nil {
+		return fmt.Errorf("Error creating catch log directory: %w", err)
+	}
+	f, err := os.OpenFile(cfg.catchLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("Error opening catch log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("Error writing catch log: %w", err)
+	}
+	return nil
+}
+
+// A line that doesn't parse (say, cut short by a crash mid-write) is skipped
+// rather than hiding the rest of the history
+func readCatchLog(path string) ([]catchAttempt, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading catch log: %w", err)
+	}
+	defer f.Close()
+
+	var attempts []catchAttempt
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var attempt catchAttempt
+		if err := json.Unmarshal(scanner.Bytes(), &attempt); err != nil {
+			continue
+		}
+		attempts = append(attempts, attempt)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Error reading catch log: %w", err)
+	}
+	return attempts, nil
+}
+
+type speciesAttempts struct {
+	name     string
+	attempts int
+	caught   int
+}
+
+type catchStats struct {
+	attempts          int
+	caught            int
+	currentStreak     int
+	currentStreakWins bool
+	longestCatches    int
+	longestEscapes    int
+	species           []speciesAttempts
+}
+
+// Attempts are assumed to be in the order they happened, as they are in the log
+func summarizeAttempts(attempts []catchAttempt) catchStats {
+	var stats catchStats
+	bySpecies := make(map[string]*speciesAttempts)
+	for _, attempt := range attempts {
+		stats.attempts++
+		s, ok := bySpecies[attempt.Pokemon]
+		if !ok {
+			s = &speciesAttempts{name: attempt.Pokemon}
+			bySpecies[attempt.Pokemon] = s
+		}
+		s.attempts++
+		if attempt.Caught {
+			stats.caught++
+			s.caught++
+		}
+
+		if stats.currentStreak > 0 && stats.currentStreakWins == attempt.Caught {
+			stats.currentStreak++
+		} else {
+			stats.currentStreak = 1
+			stats.currentStreakWins = attempt.Caught
+		}
+		if attempt.Caught {
+			stats.longestCatches = max(stats.longestCatches, stats.currentStreak)
+		} else {
+			stats.longestEscapes = max(stats.longestEscapes, stats.currentStreak)
+		}
+	}
+
+	for _, s := range bySpecies {
+		stats.species = append(stats.species, *s)
+	}
+	sort.Slice(stats.species, func(i, j int) bool {
+		if stats.species[i].attempts != stats.species[j].attempts {
+			return stats.species[i].attempts > stats.species[j].attempts
+		}
+		return stats.species[i].name < stats.species[j].name
+	})
+	return stats
+}
+
+// Species that got away most often, worst first
+func (stats catchStats) mostEscaped() []speciesAttempts {
+	var escaped []speciesAttempts
+	for _, s := range stats.species {
+		if s.attempts > s.caught {
+			escaped = append(escaped, s)
+		}
+	}
+	sort.SliceStable(escaped, func(i, j int) bool {
+		return escaped[i].attempts-escaped[i].caught > escaped[j].attempts-escaped[j].caught
+	})
+	if len(escaped) > mostEscapedShown {
+		escaped = escaped[:mostEscapedShown]
+	}
+	return escaped
+}
+
+func commandStats(cfg *config, args ...string) error {
+	attempts, err := readCatchLog(cfg.catchLogPath)
+	if err != nil {
+		return err
+	}
+	stats := summarizeAttempts(attempts)
+	if cfg.jsonOutput() {
+		return printStatsJSON(stats)
+	}
+	if len(attempts) == 0 {
+		fmt.Printf("No catch attempts yet. Try 'catch <pokemon>'\n\n")
+		return nil
+	}
+
+	fmt.Println(cfg.out.heading("Catch statistics"))
+	fmt.Printf("Attempts: %d, caught: %d (%s)\n", stats.attempts, stats.caught, percent(stats.caught, stats.attempts))
+	streak := "escapes"
+	if stats.currentStreakWins {
+		streak = "catches"
+	}
+	fmt.Printf("Current streak: %d %s\n", stats.currentStreak, streak)
+	fmt.Printf("Longest streaks: %d catches, %d escapes\n\n", stats.longestCatches, stats.longestEscapes)
+
+	rows := [][]string{headings(cfg, []string{"POKEMON", "ATTEMPTS", "CAUGHT", "RATE"})}
+	for _, s := range stats.species {
+		rows = append(rows, []string{s.name, fmt.Sprint(s.attempts), fmt.Sprint(s.caught), percent(s.caught, s.attempts)})
+	}
+	printTable(rows, 1, 2, 3)
+
+	if escaped := stats.mostEscaped(); len(escaped) > 0 {
+		fmt.Printf("\n%s\n", cfg.out.heading("Most escaped"))
+		for _, s := range escaped {
+			fmt.Printf("- %s: %d escapes\n", s.name, s.attempts-s.caught)
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func printStatsJSON(stats catchStats) error {
+	type speciesJSON struct {
+		Name     string `json:"name"`
+		Attempts int    `json:"attempts"`
+		Caught   int    `json:"caught"`
+	}
+	toJSON := func(species []speciesAttempts) []speciesJSON {
+		out := make([]speciesJSON, 0, len(species))
+		for _, s := range species {
+			out = append(out, speciesJSON{Name: s.name, Attempts: s.attempts, Caught: s.caught})
+		}
+		return out
+	}
+	return printJSON(struct {
+		Attempts          int           `json:"attempts"`
+		Caught            int           `json:"caught"`
+		CurrentStreak     int           `json:"current_streak"`
+		CurrentStreakWins bool          `json:"current_streak_catches"`
+		LongestCatches    int           `json:"longest_catch_streak"`
+		LongestEscapes    int           `json:"longest_escape_streak"`
+		Species           []speciesJSON `json:"species"`
+		MostEscaped       []speciesJSON `json:"most_escaped"`
+	}{
+		Attempts:          stats.attempts,
+		Caught:            stats.caught,
+		CurrentStreak:     stats.currentStreak,
+		CurrentStreakWins: stats.currentStreakWins,
+		LongestCatches:    stats.longestCatches,
+		LongestEscapes:    stats.longestEscapes,
+		Species:           toJSON(stats.species),
+		MostEscaped:       toJSON(stats.mostEscaped()),
+	})
+}
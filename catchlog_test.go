@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestCatchLogRecordsEveryAttempt(t *testing.T) {
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				// Rolls are 50-250, so these always escape and always get caught
+				if pokemonName == "mewtwo" {
+					return pokeapi.Pokemon{ID: 150, Name: pokemonName, BaseExperience: 1000}, nil
+				}
+				return pokeapi.Pokemon{ID: 10, Name: pokemonName, BaseExperience: 0}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+		catchLogPath:  filepath.Join(t.TempDir(), "catches.jsonl"),
+		currentArea:   "cerulean-cave",
+	}
+
+	for _, name := range []string{"caterpie", "mewtwo", "mewtwo"} {
+		if err := commandCatch(cfg, name); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	attempts, err := readCatchLog(cfg.catchLogPath)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(attempts) != 3 {
+		t.Fatalf("Expected 3 logged attempts, got %d", len(attempts))
+	}
+	first := attempts[0]
+	if first.Pokemon != "caterpie" || !first.Caught || first.Area != "cerulean-cave" || first.Roll < 50 {
+		t.Errorf("Unexpected first attempt %+v", first)
+	}
+	if attempts[1].Caught || attempts[1].BaseExperience != 1000 {
+		t.Errorf("Expected mewtwo to escape, got %+v", attempts[1])
+	}
+}
+
+func TestReadCatchLogSkipsBrokenLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catches.jsonl")
+	log := `{"pokemon":"pidgey","caught":true}
+{"pokemon":"ratt
+{"pokemon":"spearow","caught":false}
+`
+	if err := os.WriteFile(path, []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	attempts, err := readCatchLog(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(attempts) != 2 {
+		t.Errorf("Expected the broken line to be skipped, got %d attempts", len(attempts))
+	}
+
+	missing, err := readCatchLog(filepath.Join(t.TempDir(), "missing.jsonl"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("Expected a missing log to be empty, got %v, %v", missing, err)
+	}
+}
+
+func TestSummarizeAttempts(t *testing.T) {
+	attempt := func(name string, caught bool) catchAttempt {
+		return catchAttempt{Pokemon: name, Caught: caught}
+	}
+	stats := summarizeAttempts([]catchAttempt{
+		attempt("pidgey", true),
+		attempt("pidgey", true),
+		attempt("abra", false),
+		attempt("abra", false),
+		attempt("abra", false),
+		attempt("pidgey", true),
+		attempt("snorlax", false),
+		attempt("pidgey", true),
+	})
+
+	if stats.attempts != 8 || stats.caught != 4 {
+		t.Errorf("Expected 4/8 caught, got %d/%d", stats.caught, stats.attempts)
+	}
+	if stats.currentStreak != 1 || !stats.currentStreakWins {
+		t.Errorf("Expected a current streak of 1 catch, got %d (wins=%v)", stats.currentStreak, stats.currentStreakWins)
+	}
+	if stats.longestCatches != 2 || stats.longestEscapes != 3 {
+		t.Errorf("Expected longest streaks 2 and 3, got %d and %d", stats.longestCatches, stats.longestEscapes)
+	}
+	if stats.species[0].name != "pidgey" || stats.species[0].attempts != 4 {
+		t.Errorf("Expected pidgey first with 4 attempts, got %+v", stats.species[0])
+	}
+
+	escaped := stats.mostEscaped()
+	if len(escaped) != 2 || escaped[0].name != "abra" || escaped[1].name != "snorlax" {
+		t.Errorf("Expected abra then snorlax as most escaped, got %+v", escaped)
+	}
+}
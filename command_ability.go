@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func commandAbility(cfg *config, args ...string) error {
+	name := firstArg(args)
+	if name == "" {
+		fmt.Printf("Please provide the name of an ability\n\n")
+		return nil
+	}
+
+	ability, err := cfg.pokeClient.GetAbility(context.Background(), name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no ability called '%s'.%s\n\n", name, didYouMean(cfg.resourceSuggestions(pokeapi.KindAbility, name)))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error fetching ability '%s': %w", name, err)
+	}
+
+	fmt.Printf("Name: %s\n", cfg.out.heading(ability.Name))
+	if effect := ability.EffectText(); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+
+	fmt.Printf("Pokemon with %s (%d):\n", ability.Name, len(ability.Pokemon))
+	for _, holder := range ability.Pokemon {
+		line := "- " + holder.Pokemon.Name
+		if holder.IsHidden {
+			line += " (hidden)"
+		}
+		if _, caught := cfg.caughtPokemon[holder.Pokemon.Name]; caught {
+			line += " " + cfg.out.success("[caught]")
+		}
+		fmt.Println(line)
+	}
+	fmt.Println()
+	return nil
+}
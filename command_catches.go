@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+func commandRelease(cfg *config, args ...string) error {
+	ref := firstArg(args)
+	if ref == "" {
+		fmt.Printf("Please provide the catch ID or nickname of the Pokemon to release\n\n")
+		return nil
+	}
+
+	record, ok := cfg.findCatch(ref)
+	if !ok {
+		fmt.Printf("No caught Pokemon matches '%s'\n\n", ref)
+		return nil
+	}
+
+	released, _ := cfg.releaseCatch(record.ID)
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s was released. Bye!\n\n", released.ID, released.displayName())
+	return nil
+}
+
+func commandRename(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		fmt.Printf("Please provide the catch ID or nickname of the Pokemon to rename\n\n")
+		return nil
+	}
+
+	record, ok := cfg.findCatch(args[0])
+	if !ok {
+		fmt.Printf("No caught Pokemon matches '%s'\n\n", args[0])
+		return nil
+	}
+
+	nickname := strings.Join(args[1:], " ")
+	if err := cfg.validateNickname(nickname, record.ID); err != nil {
+		return err
+	}
+
+	record.Nickname = nickname
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	if nickname == "" {
+		fmt.Printf("#%d %s no longer has a nickname\n\n", record.ID, record.Species)
+	} else {
+		fmt.Printf("#%d %s is now called %s\n\n", record.ID, record.Species, nickname)
+	}
+	return nil
+}
+
+// Nicknames double as handles for commands, so they can't look like an ID,
+// contain spaces (commands take them as a single word) or collide with
+// another catch
+func (cfg *config) validateNickname(nickname string, ownerID int) error {
+	if nickname == "" {
+		return nil
+	}
+	if strings.ContainsFunc(nickname, unicode.IsSpace) {
+		return fmt.Errorf("Nickname '%s' can't contain spaces", nickname)
+	}
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return fmt.Errorf("Nickname '%s' can't be a number", nickname)
+	}
+	if other, ok := cfg.findCatch(nickname); ok && other.ID != ownerID {
+		return fmt.Errorf("Nickname '%s' is already used by #%d", nickname, other.ID)
+	}
+	return nil
+}
+
+func commandSummary(cfg *config, args ...string) error {
+	ref := firstArg(args)
+	if ref == "" {
+		fmt.Printf("Please provide the catch ID or nickname of the Pokemon to summarize\n\n")
+		return nil
+	}
+
+	record, ok := cfg.findCatch(ref)
+	if !ok {
+		fmt.Printf("No caught Pokemon matches '%s'\n\n", ref)
+		return nil
+	}
+
+	fmt.Printf("#%d %s\n", record.ID, record.displayName())
+	fmt.Printf("Level: %d\n", record.Level)
+	fmt.Printf("Nature: %s\n", record.Nature)
+	if record.Ability != "" {
+		fmt.Printf("Ability: %s\n", record.Ability)
+	}
+	if record.Item != "" {
+		fmt.Printf("Item: %s\n", record.Item)
+	}
+	fmt.Printf("Caught: %s", record.CaughtAt.Format("2006-01-02 15:04"))
+	if record.Location != "" {
+		fmt.Printf(" in %s", record.Location)
+	}
+	fmt.Println()
+	fmt.Printf("IVs:\n")
+	for _, stat := range cfg.caughtPokemon[record.Species].Stats {
+		fmt.Printf("- %s: %d\n", stat.Stat.Name, record.IVs[stat.Stat.Name])
+	}
+	if len(record.EVs) > 0 {
+		fmt.Printf("EVs: %s\n", showdownSpread(record.EVs, 0))
+	}
+	if len(record.Moves) > 0 {
+		fmt.Printf("Moves: %s\n", strings.Join(record.Moves, ", "))
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func commandCompare(cfg *config, args ...string) error {
+	if len(args) < 2 {
+		fmt.Printf("Please provide the names of two Pokemon to compare\n\n")
+		return nil
+	}
+
+	a, err := cfg.lookupPokemon(args[0])
+	if err != nil || a.Name == "" {
+		return err
+	}
+	b, err := cfg.lookupPokemon(args[1])
+	if err != nil || b.Name == "" {
+		return err
+	}
+
+	fmt.Printf("Comparing %s and %s...\n\n", a.Name, b.Name)
+
+	rows := [][]string{headings(cfg, []string{"STAT", a.Name, b.Name, "DELTA"})}
+	totalA, totalB := 0, 0
+	for _, stat := range statNames {
+		valueA, _ := a.BaseStat(stat)
+		valueB, _ := b.BaseStat(stat)
+		totalA += valueA
+		totalB += valueB
+		rows = append(rows, []string{stat, cfg.out.stat(valueA), cfg.out.stat(valueB), fmt.Sprintf("%+d", valueA-valueB)})
+	}
+	rows = append(rows, []string{"total", fmt.Sprint(totalA), fmt.Sprint(totalB), fmt.Sprintf("%+d", totalA-totalB)})
+	printTable(rows, 1, 2, 3)
+
+	fmt.Printf("\nTypes: %s is %s, %s is %s\n", a.Name, cfg.out.typeNames(a.TypeNames()), b.Name, cfg.out.typeNames(b.TypeNames()))
+	fmt.Printf("Type matchup:\n")
+	for _, pair := range [][2]pokeapi.Pokemon{{a, b}, {b, a}} {
+		attackType, multiplier, err := cfg.bestMatchup(pair[0], pair[1])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("- %s's best %s moves deal %gx to %s\n", pair[0].Name, cfg.out.typeName(attackType), multiplier, pair[1].Name)
+	}
+	fmt.Println()
+	return nil
+}
+
+// Finds which of the attacker's types hits the defender hardest, combining
+// the multipliers against each of the defender's types
+func (cfg *config) bestMatchup(attacker, defender pokeapi.Pokemon) (string, float64, error) {
+	bestType, best := "", -1.0
+	for _, typeName := range attacker.TypeNames() {
+		attackType, err := cfg.pokeClient.GetType(context.Background(), typeName)
+		if err != nil {
+			return "", 0, fmt.Errorf("Error fetching type '%s': %w", typeName, err)
+		}
+		multiplier := 1.0
+		for _, defending := range defender.TypeNames() {
+			multiplier *= attackType.Multiplier(defending)
+		}
+		if multiplier > best {
+			bestType, best = typeName, multiplier
+		}
+	}
+	return bestType, best, nil
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+// Sections of the stored snapshot that --all turns on
+var inspectSections = []string{"abilities", "items", "moves", "forms", "sprites"}
+
+// Flags that aren't sections. --sprite downloads the sprite, so --all leaves
+// it out to keep inspect offline.
+var inspectOptions = []string{"all", "sprite", "rank", "refresh"}
+
+func commandInspect(cfg *config, args ...string) error {
+	positional, flags := splitFlags(args)
+	pokemonName := firstArg(positional)
+	if pokemonName == "" {
+		fmt.Printf("Please provide the name of the Pokemon to inspect\n\n")
+		return nil
+	}
+	for flag := range flags {
+		if !slices.Contains(inspectOptions, flag) && !slices.Contains(inspectSections, flag) {
+			return fmt.Errorf("Unknown flag '--%s', expected one of --%s", flag, strings.Join(append(slices.Clone(inspectSections), inspectOptions...), ", --"))
+		}
+	}
+
+	pokemon, ok := cfg.findCaught(pokemonName)
+	if !ok {
+		fmt.Printf("You haven't caught '%s' yet.%s\n\n", pokemonName, didYouMean(suggestNames(pokemonName, cfg.caughtSpeciesNames(), maxSuggestions)))
+		return nil
+	}
+	pokemonName = pokemon.Name
+
+	// Inspect renders the snapshot stored at catch time; --refresh re-syncs it
+	if flags["refresh"] != "" {
+		fmt.Printf("Refreshing %s from PokeAPI...\n", pokemonName)
+		fresh, err := cfg.pokeClient.GetPokemonInfo(pokemonName)
+		if err != nil {
+			return fmt.Errorf("Error fetching Pokemon '%s': %w", pokemonName, err)
+		}
+		pokemon = fresh
+		cfg.caughtPokemon[pokemonName] = pokemon
+		if err := cfg.save(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Inspecting %s...\n\n", pokemonName)
+
+	fmt.Printf("Name: %s\n", cfg.out.heading(pokemon.Name))
+	if pokemon.Species.Name != "" && pokemon.Species.Name != pokemon.Name {
+		fmt.Printf("Species: %s\n", pokemon.Species.Name)
+	}
+	fmt.Printf("Times caught: %d\n", cfg.timesCaught(pokemonName))
+	fmt.Printf("ID: %d\n", pokemon.ID)
+	fmt.Printf("Base Experience: %d\n", pokemon.BaseExperience)
+	fmt.Printf("Height: %.1f m\n", pokemon.HeightMeters())
+	fmt.Printf("Weight: %.1f kg\n", pokemon.WeightKilograms())
+	if err := cfg.printStatBars(pokemon, flags["rank"] != ""); err != nil {
+		return err
+	}
+	fmt.Printf("Types:\n")
+	for _, typeInfo := range pokemon.Types {
+		fmt.Printf("- %s\n", cfg.out.typeName(typeInfo.Type.Name))
+	}
+
+	fmt.Printf("Your catches:\n")
+	for _, record := range cfg.catchesOf(pokemonName) {
+		fmt.Printf("- #%d %s, Lv %d, %s\n", record.ID, record.displayName(), record.Level, record.Nature)
+	}
+
+	show := func(section string) bool {
+		return flags["all"] != "" || flags[section] != ""
+	}
+	if show("abilities") {
+		printAbilities(pokemon)
+	}
+	if show("items") {
+		printHeldItems(pokemon)
+	}
+	if show("moves") {
+		printMoves(pokemon)
+	}
+	if show("forms") {
+		printForms(pokemon)
+	}
+	if show("sprites") {
+		printSprites(pokemon)
+	}
+	if flags["sprite"] != "" {
+		if err := cfg.printSprite(pokemon); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
+
+const (
+	maxBaseStat  = 255
+	statBarWidth = 30
+)
+
+// Each stat is drawn as a bar scaled to the highest possible base stat. With
+// rank, stats are also placed against every Pokemon using the saved aggregate.
+func (cfg *config) printStatBars(pokemon pokeapi.Pokemon, rank bool) error {
+	var aggregate *pokeapi.StatAggregate
+	if rank {
+		fmt.Printf("Ranking against every Pokemon (the first time fetches them all, this takes a while, Ctrl+C to stop)...\n")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		a, err := cfg.statAggregate(ctx)
+		stop()
+		if err != nil {
+			return fmt.Errorf("Error building stat rankings: %w", err)
+		}
+		if a.Skipped > 0 {
+			fmt.Printf("⚠️ %d Pokemon couldn't be fetched and are left out of the rankings\n", a.Skipped)
+		}
+		aggregate = &a
+	}
+
+	percentile := func(stat string, value int) string {
+		if aggregate == nil {
+			return ""
+		}
+		p, ok := aggregate.Percentile(stat, value)
+		if !ok {
+			return ""
+		}
+		return fmt.Sprintf("higher than %.0f%%", p)
+	}
+
+	fmt.Printf("Stats:\n")
+	rows := [][]string{}
+	for _, stat := range pokemon.Stats {
+		rows = append(rows, []string{
+			stat.Stat.Name,
+			cfg.out.stat(stat.BaseStat),
+			cfg.out.bar(stat.BaseStat, maxBaseStat, statBarWidth),
+			percentile(stat.Stat.Name, stat.BaseStat),
+		})
+	}
+	total := pokemon.BaseStatTotal()
+	rows = append(rows, []string{cfg.out.heading("total"), cfg.out.heading(fmt.Sprint(total)), "", percentile(pokeapi.StatTotal, total)})
+	printTable(rows, 1)
+	return nil
+}
+
+func printAbilities(pokemon pokeapi.Pokemon) {
+	fmt.Printf("Abilities:\n")
+	for _, ability := range pokemon.Abilities {
+		if ability.IsHidden {
+			fmt.Printf("- %s (hidden)\n", ability.Ability.Name)
+		} else {
+			fmt.Printf("- %s\n", ability.Ability.Name)
+		}
+	}
+}
+
+func printHeldItems(pokemon pokeapi.Pokemon) {
+	fmt.Printf("Held items:\n")
+	if len(pokemon.HeldItems) == 0 {
+		fmt.Printf("- none\n")
+	}
+	for _, held := range pokemon.HeldItems {
+		fmt.Printf("- %s\n", held.Item.Name)
+	}
+}
+
+// Moves are listed with every distinct way they can be learned across games
+func printMoves(pokemon pokeapi.Pokemon) {
+	fmt.Printf("Moves (%d):\n", len(pokemon.Moves))
+	for _, move := range pokemon.Moves {
+		var methods []string
+		var levels []string
+		for _, detail := range move.VersionGroupDetails {
+			method := detail.MoveLearnMethod.Name
+			if method == "level-up" && detail.LevelLearnedAt > 0 {
+				level := fmt.Sprint(detail.LevelLearnedAt)
+				if !slices.Contains(levels, level) {
+					levels = append(levels, level)
+				}
+			}
+			if !slices.Contains(methods, method) {
+				methods = append(methods, method)
+			}
+		}
+		if i := slices.Index(methods, "level-up"); i >= 0 && len(levels) > 0 {
+			methods[i] = "level-up Lv " + strings.Join(levels, "/")
+		}
+		fmt.Printf("- %s (%s)\n", move.Move.Name, strings.Join(methods, ", "))
+	}
+}
+
+func printForms(pokemon pokeapi.Pokemon) {
+	fmt.Printf("Forms:\n")
+	for _, form := range pokemon.Forms {
+		fmt.Printf("- %s\n", form.Name)
+	}
+}
+
+func printSprites(pokemon pokeapi.Pokemon) {
+	sprites := []struct {
+		label string
+		url   *string
+	}{
+		{label: "front", url: pokemon.Sprites.FrontDefault},
+		{label: "front shiny", url: pokemon.Sprites.FrontShiny},
+		{label: "back", url: pokemon.Sprites.BackDefault},
+		{label: "back shiny", url: pokemon.Sprites.BackShiny},
+		{label: "official artwork", url: pokemon.Sprites.Other.OfficialArtwork.FrontDefault},
+	}
+
+	fmt.Printf("Sprites:\n")
+	for _, sprite := range sprites {
+		if sprite.url != nil {
+			fmt.Printf("- %s: %s\n", sprite.label, *sprite.url)
+		}
+	}
+}
+
+func (cfg *config) printSprite(pokemon pokeapi.Pokemon) error {
+	if pokemon.Sprites.FrontDefault == nil {
+		fmt.Printf("No sprite available for %s\n", pokemon.Name)
+		return nil
+	}
+
+	data, err := cfg.pokeClient.GetSprite(context.Background(), *pokemon.Sprites.FrontDefault)
+	if err != nil {
+		return fmt.Errorf("Error fetching sprite for '%s': %w", pokemon.Name, err)
+	}
+	img, err := decodeSprite(data)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Print(renderSprite(img, cfg.out.enabled()))
+	return nil
+}
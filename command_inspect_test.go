@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+// Runs fn with os.Stdout redirected and returns what it printed
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+	fn()
+	w.Close()
+	return <-output
+}
+
+func TestCommandInspectFlags(t *testing.T) {
+	// The heading each optional section prints
+	sectionHeadings := map[string]string{
+		"abilities": "Abilities:",
+		"items":     "Held items:",
+		"moves":     "Moves (",
+		"forms":     "Forms:",
+		"sprites":   "Sprites:",
+	}
+
+	tests := []struct {
+		name             string
+		args             []string
+		expectedSections []string
+		expectedError    bool
+	}{
+		{name: "no flags", args: []string{"pikachu"}},
+		{name: "moves section", args: []string{"pikachu", "--moves"}, expectedSections: []string{"moves"}},
+		{name: "two sections", args: []string{"pikachu", "--forms", "--abilities"}, expectedSections: []string{"abilities", "forms"}},
+		{name: "all sections", args: []string{"25", "--all"}, expectedSections: []string{"abilities", "items", "moves", "forms", "sprites"}},
+		{name: "refresh", args: []string{"pikachu", "--refresh"}},
+		{name: "rank", args: []string{"pikachu", "--rank"}},
+		{name: "unknown flag", args: []string{"pikachu", "--evolutions"}, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pikachu := pokeapi.Pokemon{ID: 25, Name: "pikachu", Height: 4, Weight: 60}
+			cfg := &config{
+				pokeClient: &mockClient{
+					getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+						return pikachu, nil
+					},
+				},
+				caughtPokemon: map[string]pokeapi.Pokemon{"pikachu": pikachu},
+				catches:       []catchRecord{{ID: 1, Species: "pikachu"}},
+			}
+
+			var err error
+			output := captureStdout(t, func() {
+				err = commandInspect(cfg, test.args...)
+			})
+			if test.expectedError {
+				if err == nil {
+					t.Errorf("Expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error but got %v", err)
+			}
+
+			for section, heading := range sectionHeadings {
+				expected := slices.Contains(test.expectedSections, section)
+				if shown := strings.Contains(output, heading); shown != expected {
+					t.Errorf("Expected %s section shown=%v, got %v in:\n%s", section, expected, shown, output)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandInspectAllStaysOffline(t *testing.T) {
+	spriteURL := "https://example.com/pikachu.png"
+	pikachu := pokeapi.Pokemon{ID: 25, Name: "pikachu", Sprites: pokeapi.PokemonSprites{FrontDefault: &spriteURL}}
+	downloads := 0
+	cfg := &config{
+		pokeClient: &mockClient{
+			getSpriteFunc: func(url string) ([]byte, error) {
+				downloads++
+				return nil, errors.New("offline")
+			},
+		},
+		caughtPokemon: map[string]pokeapi.Pokemon{"pikachu": pikachu},
+	}
+
+	captureStdout(t, func() {
+		if err := commandInspect(cfg, "pikachu", "--all"); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+	if downloads != 0 {
+		t.Errorf("Expected --all not to download the sprite, got %d downloads", downloads)
+	}
+
+	captureStdout(t, func() {
+		commandInspect(cfg, "pikachu", "--sprite")
+	})
+	if downloads != 1 {
+		t.Errorf("Expected --sprite to download the sprite once, got %d downloads", downloads)
+	}
+}
+
+func TestCommandInspectUsesStoredSnapshot(t *testing.T) {
+	calls := 0
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				calls++
+				return pokeapi.Pokemon{ID: 25, Name: "pikachu", BaseExperience: 112}, nil
+			},
+		},
+		caughtPokemon: map[string]pokeapi.Pokemon{"pikachu": {ID: 25, Name: "pikachu"}},
+		catches:       []catchRecord{{ID: 1, Species: "pikachu"}},
+	}
+
+	if err := commandInspect(cfg, "pikachu"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("Expected inspect to render offline, got %d API calls", calls)
+	}
+
+	if err := commandInspect(cfg, "pikachu", "--refresh"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected --refresh to make 1 API call, got %d", calls)
+	}
+	if cfg.caughtPokemon["pikachu"].BaseExperience != 112 {
+		t.Error("Expected --refresh to update the stored snapshot")
+	}
+}
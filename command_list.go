@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func commandList(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		fmt.Printf("Please provide what to list: %s\n\n", listableKinds())
+		return nil
+	}
+
+	kind, ok := pokeapi.ParseResourceKind(args[0])
+	if !ok {
+		return fmt.Errorf("Unknown resource '%s', expected one of: %s", args[0], listableKinds())
+	}
+
+	pages := cfg.paginator(string(kind), func(pageURL *string) (pokeapi.NamedAPIResourceList, error) {
+		return cfg.pokeClient.ListResources(context.Background(), kind, pageURL)
+	})
+
+	direction := "next"
+	if len(args) > 1 {
+		direction = args[1]
+	}
+
+	var page pokeapi.NamedAPIResourceList
+	var err error
+	switch direction {
+	case "next":
+		page, err = pages.Next()
+	case "prev", "back":
+		page, err = pages.Previous()
+	default:
+		return fmt.Errorf("Unknown direction '%s', expected 'next' or 'prev'", direction)
+	}
+
+	if errors.Is(err, pokeapi.ErrNoNextPage) {
+		fmt.Println("You're on the last page")
+		return nil
+	}
+	if errors.Is(err, pokeapi.ErrNoPreviousPage) {
+		fmt.Println("You're on the first page")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error listing %s: %w", kind, err)
+	}
+
+	for _, resource := range page.Results {
+		fmt.Println(resource.Name)
+	}
+	fmt.Println()
+
+	return nil
+}
+
+func listableKinds() string {
+	names := make([]string, 0, len(pokeapi.ListableKinds))
+	for _, kind := range pokeapi.ListableKinds {
+		names = append(names, string(kind))
+	}
+	return strings.Join(names, ", ")
+}
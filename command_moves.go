@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func commandMove(cfg *config, args ...string) error {
+	name := firstArg(args)
+	if name == "" {
+		fmt.Printf("Please provide the name of a move\n\n")
+		return nil
+	}
+
+	move, err := cfg.pokeClient.GetMove(context.Background(), name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no move called '%s'.%s\n\n", name, didYouMean(cfg.resourceSuggestions(pokeapi.KindMove, name)))
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error fetching move '%s': %w", name, err)
+	}
+
+	fmt.Printf("Name: %s\n", cfg.out.heading(move.Name))
+	fmt.Printf("Type: %s\n", cfg.out.typeName(move.Type.Name))
+	fmt.Printf("Damage class: %s\n", move.DamageClass.Name)
+	fmt.Printf("Power: %s\n", optionalStat(move.Power))
+	fmt.Printf("Accuracy: %s\n", optionalStat(move.Accuracy))
+	fmt.Printf("PP: %s\n", optionalStat(move.PP))
+	if move.Priority != 0 {
+		fmt.Printf("Priority: %+d\n", move.Priority)
+	}
+	if effect := move.EffectText(); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+	fmt.Println()
+	return nil
+}
+
+// Status moves have no power and some moves never miss; PokeAPI sends null
+func optionalStat(value *int) string {
+	if value == nil {
+		return "-"
+	}
+	return fmt.Sprint(*value)
+}
+
+type learnsetEntry struct {
+	level int
+	move  string
+}
+
+func commandLearnset(cfg *config, args ...string) error {
+	positional, flags := splitFlags(args)
+	name := firstArg(positional)
+	if name == "" {
+		fmt.Printf("Please provide the name of a Pokemon\n\n")
+		return nil
+	}
+	for flag := range flags {
+		if flag != "details" {
+			return fmt.Errorf("Unknown flag '--%s', expected --details", flag)
+		}
+	}
+
+	pokemon, err := cfg.lookupPokemon(name)
+	if err != nil || pokemon.Name == "" {
+		return err
+	}
+
+	learnsets := levelUpLearnsets(pokemon)
+	if len(learnsets) == 0 {
+		fmt.Printf("%s doesn't learn any moves by level-up\n\n", pokemon.Name)
+		return nil
+	}
+
+	versionGroup := ""
+	if len(positional) > 1 {
+		versionGroup = positional[1]
+	} else if versionGroup, err = cfg.latestVersionGroup(learnsets); err != nil {
+		return err
+	}
+
+	entries, ok := learnsets[versionGroup]
+	if !ok {
+		return fmt.Errorf("%s learns no moves by level-up in '%s', available version groups: %s",
+			pokemon.Name, versionGroup, strings.Join(learnsetGroups(learnsets), ", "))
+	}
+
+	fmt.Printf("%s level-up moves in %s:\n\n", pokemon.Name, versionGroup)
+	header := []string{"LEVEL", "MOVE"}
+	if flags["details"] != "" {
+		header = append(header, "TYPE", "CLASS", "POWER", "ACC", "PP")
+	}
+	rows := [][]string{headings(cfg, header)}
+	for _, entry := range entries {
+		row := []string{fmt.Sprint(entry.level), entry.move}
+		if flags["details"] != "" {
+			move, err := cfg.pokeClient.GetMove(context.Background(), entry.move)
+			if err != nil {
+				return fmt.Errorf("Error fetching move '%s': %w", entry.move, err)
+			}
+			row = append(row, cfg.out.typeName(move.Type.Name), move.DamageClass.Name,
+				optionalStat(move.Power), optionalStat(move.Accuracy), optionalStat(move.PP))
+		}
+		rows = append(rows, row)
+	}
+	printTable(rows, 0, 4, 5, 6)
+	fmt.Println()
+	return nil
+}
+
+// Groups level-up moves by version group, each sorted by level then name
+func levelUpLearnsets(pokemon pokeapi.Pokemon) map[string][]learnsetEntry {
+	learnsets := make(map[string][]learnsetEntry)
+	for _, move := range pokemon.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" {
+				continue
+			}
+			group := detail.VersionGroup.Name
+			learnsets[group] = append(learnsets[group], learnsetEntry{level: detail.LevelLearnedAt, move: move.Move.Name})
+		}
+	}
+	for _, entries := range learnsets {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].level != entries[j].level {
+				return entries[i].level < entries[j].level
+			}
+			return entries[i].move < entries[j].move
+		})
+	}
+	return learnsets
+}
+
+func learnsetGroups(learnsets map[string][]learnsetEntry) []string {
+	groups := make([]string, 0, len(learnsets))
+	for group := range learnsets {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+// PokeAPI lists version groups oldest first, so the last one present wins
+func (cfg *config) latestVersionGroup(learnsets map[string][]learnsetEntry) (string, error) {
+	ordered, err := cfg.pokeClient.GetResourceNames(context.Background(), pokeapi.KindVersionGroup)
+	if err != nil {
+		return "", fmt.Errorf("Error fetching version groups: %w", err)
+	}
+	latest := ""
+	for _, group := range ordered {
+		if _, ok := learnsets[group]; ok {
+			latest = group
+		}
+	}
+	if latest == "" {
+		// None of them are in the index, so there's no telling which is newest
+		return "", fmt.Errorf("None of these version groups are in PokeAPI's index, please choose one: %s",
+			strings.Join(learnsetGroups(learnsets), ", "))
+	}
+	return latest, nil
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+var pokedexSortKeys = []string{"id", "name", "caught", "exp"}
+
+func commandPokedex(cfg *config, args ...string) error {
+	_, flags := splitFlags(args, "gen", "sort", "type", "min-stat")
+	switch {
+	case flags["progress"] != "":
+		return printProgress(cfg)
+	case flags["missing"] != "":
+		return printMissing(cfg, flags["gen"])
+	}
+
+	if len(cfg.caughtPokemon) == 0 && !cfg.jsonOutput() {
+		fmt.Printf("You haven't caught any Pokemon yet\n\n")
+		return nil
+	}
+
+	minStats, err := parseMinStats(flags["min-stat"])
+	if err != nil {
+		return err
+	}
+
+	var entries []pokeapi.Pokemon
+	for _, pokemon := range cfg.caughtPokemon {
+		if flags["type"] != "" && !slices.Contains(pokemon.TypeNames(), flags["type"]) {
+			continue
+		}
+		if !meetsMinStats(pokemon, minStats) {
+			continue
+		}
+		entries = append(entries, pokemon)
+	}
+
+	if err := sortPokedex(cfg, entries, flags["sort"]); err != nil {
+		return err
+	}
+
+	if cfg.jsonOutput() {
+		return printPokedexJSON(cfg, entries)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("No caught Pokemon match those filters\n\n")
+		return nil
+	}
+
+	fmt.Printf("Your Pokedex:\n\n")
+	rows := [][]string{{"ID", "NAME", "TYPES", "CAUGHT", "CATCH IDS"}}
+	for _, pokemon := range entries {
+		var ids []string
+		for _, record := range cfg.catchesOf(pokemon.Name) {
+			ids = append(ids, fmt.Sprintf("#%d", record.ID))
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(pokemon.ID), pokemon.Name, cfg.out.typeNames(pokemon.TypeNames()),
+			fmt.Sprint(cfg.timesCaught(pokemon.Name)), strings.Join(ids, " "),
+		})
+	}
+	rows[0] = headings(cfg, rows[0])
+	printTable(rows, 0, 3)
+	fmt.Println()
+	return nil
+}
+
+func printPokedexJSON(cfg *config, entries []pokeapi.Pokemon) error {
+	type pokedexEntry struct {
+		ID       int      `json:"id"`
+		Name     string   `json:"name"`
+		Types    []string `json:"types"`
+		Caught   int      `json:"caught"`
+		CatchIDs []int    `json:"catch_ids"`
+	}
+	out := make([]pokedexEntry, 0, len(entries))
+	for _, pokemon := range entries {
+		entry := pokedexEntry{ID: pokemon.ID, Name: pokemon.Name, Types: pokemon.TypeNames(), CatchIDs: []int{}}
+		for _, record := range cfg.catchesOf(pokemon.Name) {
+			entry.CatchIDs = append(entry.CatchIDs, record.ID)
+		}
+		entry.Caught = len(entry.CatchIDs)
+		out = append(out, entry)
+	}
+	return printJSON(out)
+}
+
+// Sorts by the requested key, falling back to dex number and then name so the
+// order is the same every time
+func sortPokedex(cfg *config, entries []pokeapi.Pokemon, key string) error {
+	if key == "" {
+		key = "id"
+	}
+	if !slices.Contains(pokedexSortKeys, key) {
+		return fmt.Errorf("Unknown sort '%s', expected one of: %s", key, strings.Join(pokedexSortKeys, ", "))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch key {
+		case "name":
+			return a.Name < b.Name
+		case "caught":
+			if ca, cb := cfg.timesCaught(a.Name), cfg.timesCaught(b.Name); ca != cb {
+				return ca > cb
+			}
+		case "exp":
+			if a.BaseExperience != b.BaseExperience {
+				return a.BaseExperience > b.BaseExperience
+			}
+		}
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Name < b.Name
+	})
+	return nil
+}
+
+// Parses "attack=80" or "attack=80,speed=100"
+func parseMinStats(spec string) (map[string]int, error) {
+	minStats := make(map[string]int)
+	if spec == "" {
+		return minStats, nil
+	}
+	for _, part := range strings.Split(spec, ",") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("Invalid --min-stat '%s', expected <stat>=<value>", part)
+		}
+		if !slices.Contains(statNames, name) {
+			return nil, fmt.Errorf("Unknown stat '%s', expected one of: %s", name, strings.Join(statNames, ", "))
+		}
+		minimum, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for stat '%s': %w", name, err)
+		}
+		minStats[name] = minimum
+	}
+	return minStats, nil
+}
+
+func meetsMinStats(pokemon pokeapi.Pokemon, minStats map[string]int) bool {
+	for name, minimum := range minStats {
+		if value, _ := pokemon.BaseStat(name); value < minimum {
+			return false
+		}
+	}
+	return true
+}
+
+func printProgress(cfg *config) error {
+	ctx := context.Background()
+	seen, caught := cfg.speciesProgress()
+
+	allSpecies, err := cfg.pokeClient.GetResourceNames(ctx, pokeapi.KindPokemonSpecies)
+	if err != nil {
+		return fmt.Errorf("Error fetching species list: %w", err)
+	}
+	fmt.Printf("National Pokedex: %s\n\n", completion(allSpecies, seen, caught))
+
+	generations, err := cfg.pokeClient.GetResourceNames(ctx, pokeapi.KindGeneration)
+	if err != nil {
+		return fmt.Errorf("Error fetching generations: %w", err)
+	}
+	for _, name := range generations {
+		generation, err := cfg.pokeClient.GetGeneration(ctx, name)
+		if err != nil {
+			return fmt.Errorf("Error fetching generation '%s': %w", name, err)
+		}
+		species := make([]string, 0, len(generation.PokemonSpecies))
+		for _, s := range generation.PokemonSpecies {
+			species = append(species, s.Name)
+		}
+		fmt.Printf("%s (%s): %s\n", generation.Name, generation.MainRegion.Name, completion(species, seen, caught))
+	}
+	fmt.Println()
+	return nil
+}
+
+func completion(species []string, seen, caught map[string]bool) string {
+	seenCount, caughtCount := 0, 0
+	for _, name := range species {
+		if seen[name] {
+			seenCount++
+		}
+		if caught[name] {
+			caughtCount++
+		}
+	}
+	return fmt.Sprintf("seen %d/%d (%s), caught %d/%d (%s)",
+		seenCount, len(species), percent(seenCount, len(species)),
+		caughtCount, len(species), percent(caughtCount, len(species)))
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "0.0%"
+	}
+	return fmt.Sprintf("%.1f%%", float64(part)*100/float64(total))
+}
+
+// Lists every species not caught yet, split by whether it has been seen.
+// Without --gen this covers the whole national species list.
+func printMissing(cfg *config, generationName string) error {
+	ctx := context.Background()
+	seen, caught := cfg.speciesProgress()
+
+	var species []string
+	if generationName != "" && generationName != "true" {
+		generation, err := cfg.pokeClient.GetGeneration(ctx, generationName)
+		if err != nil {
+			return fmt.Errorf("Error fetching generation '%s': %w", generationName, err)
+		}
+		for _, s := range generation.PokemonSpecies {
+			species = append(species, s.Name)
+		}
+	} else {
+		all, err := cfg.pokeClient.GetResourceNames(ctx, pokeapi.KindPokemonSpecies)
+		if err != nil {
+			return fmt.Errorf("Error fetching species list: %w", err)
+		}
+		species = all
+	}
+
+	var seenNotCaught, unseen []string
+	for _, name := range species {
+		switch {
+		case caught[name]:
+		case seen[name]:
+			seenNotCaught = append(seenNotCaught, name)
+		default:
+			unseen = append(unseen, name)
+		}
+	}
+
+	if len(seenNotCaught) == 0 && len(unseen) == 0 {
+		fmt.Printf("Nothing missing, you caught them all!\n\n")
+		return nil
+	}
+
+	fmt.Printf("Seen but not caught (%d):\n", len(seenNotCaught))
+	for _, name := range seenNotCaught {
+		fmt.Printf("- %s\n", name)
+	}
+	fmt.Printf("\nNot seen yet (%d):\n", len(unseen))
+	for _, name := range unseen {
+		fmt.Printf("- %s\n", name)
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func commandSearch(cfg *config, args ...string) error {
+	query := firstArg(args)
+	if query == "" {
+		fmt.Printf("Please provide part of a Pokemon name to search for\n\n")
+		return nil
+	}
+
+	names, err := cfg.pokeClient.GetResourceNames(context.Background(), pokeapi.KindPokemon)
+	if err != nil {
+		return fmt.Errorf("Error fetching Pokemon names: %w", err)
+	}
+
+	var matches []string
+	for _, name := range names {
+		if strings.Contains(name, query) {
+			matches = append(matches, name)
+		}
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("No Pokemon name contains '%s'.%s\n\n", query, didYouMean(suggestNames(query, names, maxSuggestions)))
+		return nil
+	}
+
+	fmt.Printf("Pokemon matching '%s':\n", query)
+	for _, name := range matches {
+		fmt.Printf("- %s\n", name)
+	}
+	fmt.Println()
+	return nil
+}
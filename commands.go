@@ -0,0 +1,330 @@
+package main
+
+// The command registry. Everything help prints comes from here, so a new
+// command only needs its entry filled in to be documented.
+
+type cliCommand struct {
+	name        string
+	description string
+	callback    func(*config, ...string) error
+	category    string
+	// Arguments after the name, e.g. "<pokemon> [--refresh]". Commands with
+	// subcommands describe each of them instead.
+	usage       string
+	subcommands []subcommand
+	args        []argSpec
+	examples    []string
+	// Arguments are passed as typed instead of lowercased (nicknames, paths)
+	preserveCase bool
+	// Changes to the Pokedex can be reverted with 'undo'
+	undoable bool
+}
+
+type subcommand struct {
+	// The subcommand and its arguments, e.g. "add <catch-id|nickname|species>"
+	usage       string
+	description string
+}
+
+type argSpec struct {
+	name        string
+	description string
+}
+
+// Help lists categories in this order, and commands alphabetically within one
+var commandCategories = []string{
+	"Exploring",
+	"Pokemon data",
+	"Catching",
+	"Your team",
+	"Sharing",
+	"Settings",
+	"Session",
+}
+
+var catchRefArg = argSpec{name: "<catch-id|nickname>", description: "A catch ID as shown by pokedex (3 or #3), or a nickname"}
+
+var commands = map[string]cliCommand{
+	"exit": {
+		name:        "exit",
+		description: "Exit the Pokedex",
+		callback:    commandExit,
+		category:    "Session",
+	},
+	"map": {
+		name:        "map",
+		description: "Displays the names of the next 20 location areas in the Pokemon world",
+		callback:    commandMap,
+		category:    "Exploring",
+	},
+	"mapb": {
+		name:        "mapb",
+		description: "Displays the names of the previous 20 location areas in the Pokemon world",
+		callback:    commandMapb,
+		category:    "Exploring",
+	},
+	"list": {
+		name:        "list",
+		description: "Page through any PokeAPI resource list",
+		callback:    commandList,
+		category:    "Exploring",
+		usage:       "<resource> [next|prev]",
+		args: []argSpec{
+			{name: "<resource>", description: listableKinds()},
+			{name: "[next|prev]", description: "Move to the next (default) or previous page"},
+		},
+		examples: []string{"list item", "list item prev"},
+	},
+	"search": {
+		name:        "search",
+		description: "Find Pokemon whose name contains the given text",
+		callback:    commandSearch,
+		category:    "Exploring",
+		usage:       "<text>",
+		examples:    []string{"search chu"},
+	},
+	"explore": {
+		name:        "explore",
+		description: "See a list of all the Pokemon located in a specific location area",
+		callback:    commandExplore,
+		category:    "Exploring",
+		usage:       "<location-area>",
+		args: []argSpec{
+			{name: "<location-area>", description: "A location area name as listed by map; catches made afterwards remember it"},
+		},
+		examples: []string{"explore viridian-forest"},
+	},
+	"catch": {
+		name:        "catch",
+		description: "Catch a Pokemon and add it to your Pokedex",
+		callback:    commandCatch,
+		category:    "Catching",
+		usage:       "<pokemon|dex-number>",
+		examples:    []string{"catch pikachu", "catch 25"},
+		undoable:    true,
+	},
+	"inspect": {
+		name:        "inspect",
+		description: "View detailed information about a caught Pokemon",
+		callback:    commandInspect,
+		category:    "Pokemon data",
+		usage:       "<pokemon|dex-number> [--abilities] [--items] [--moves] [--forms] [--sprites] [--sprite] [--all] [--rank] [--refresh]",
+		args: []argSpec{
+			{name: "--abilities, --items, --moves, --forms, --sprites", description: "Show that extra section (--all shows every one)"},
+			{name: "--sprite", description: "Download the front sprite and draw it in the terminal (not part of --all)"},
+			{name: "--rank", description: "Rank each stat against every Pokemon (the first run fetches them all)"},
+			{name: "--refresh", description: "Re-sync the stored data from PokeAPI"},
+		},
+		examples: []string{"inspect pikachu", "inspect 25 --moves --rank"},
+	},
+	"compare": {
+		name:        "compare",
+		description: "Compare the base stats and type matchup of two Pokemon, caught or not",
+		callback:    commandCompare,
+		category:    "Pokemon data",
+		usage:       "<pokemon> <pokemon>",
+		examples:    []string{"compare pikachu raichu"},
+	},
+	"move": {
+		name:        "move",
+		description: "Look up a move's type, power, accuracy, PP and effect",
+		callback:    commandMove,
+		category:    "Pokemon data",
+		usage:       "<move>",
+		examples:    []string{"move thunderbolt"},
+	},
+	"learnset": {
+		name:        "learnset",
+		description: "List the moves a Pokemon learns by level-up",
+		callback:    commandLearnset,
+		category:    "Pokemon data",
+		usage:       "<pokemon> [version-group] [--details]",
+		args: []argSpec{
+			{name: "[version-group]", description: "e.g. red-blue (defaults to the latest one the Pokemon appears in)"},
+			{name: "--details", description: "Also show each move's type, power and accuracy"},
+		},
+		examples: []string{"learnset pikachu", "learnset pikachu red-blue --details"},
+	},
+	"ability": {
+		name:        "ability",
+		description: "Look up an ability's effect and the Pokemon that can have it",
+		callback:    commandAbility,
+		category:    "Pokemon data",
+		usage:       "<ability>",
+		examples:    []string{"ability static"},
+	},
+	"pokedex": {
+		name:        "pokedex",
+		description: "List the Pokemon you have caught, sorted and filtered by type or stats, your completion per generation, or what's still missing",
+		callback:    commandPokedex,
+		category:    "Catching",
+		usage:       "[--sort id|name|caught|exp] [--type <type>] [--min-stat <stat>=<value>] [--progress] [--missing [--gen <generation>]]",
+		args: []argSpec{
+			{name: "--sort id|name|caught|exp", description: "Order of the list (dex number by default)"},
+			{name: "--type <type>", description: "Only Pokemon of this type"},
+			{name: "--min-stat <stat>=<value>", description: "Only Pokemon with at least this base stat (comma separated for several)"},
+			{name: "--progress", description: "Seen and caught counts per generation"},
+			{name: "--missing [--gen <generation>]", description: "Species not caught yet, optionally for one generation"},
+		},
+		examples: []string{"pokedex --sort caught --type electric", "pokedex --min-stat speed=90", "pokedex --missing --gen 1"},
+	},
+	"summary": {
+		name:         "summary",
+		description:  "Show the level, nature and IVs of one caught Pokemon",
+		callback:     commandSummary,
+		category:     "Catching",
+		usage:        "<catch-id|nickname>",
+		args:         []argSpec{catchRefArg},
+		examples:     []string{"summary 3", "summary sparky"},
+		preserveCase: true,
+	},
+	"rename": {
+		name:         "rename",
+		description:  "Give one caught Pokemon a nickname (leave it empty to remove it)",
+		callback:     commandRename,
+		category:     "Catching",
+		usage:        "<catch-id|nickname> [nickname]",
+		args:         []argSpec{catchRefArg},
+		examples:     []string{"rename 1 Sparky", "rename sparky"},
+		preserveCase: true,
+		undoable:     true,
+	},
+	"release": {
+		name:         "release",
+		description:  "Release one caught Pokemon",
+		callback:     commandRelease,
+		category:     "Catching",
+		usage:        "<catch-id|nickname>",
+		args:         []argSpec{catchRefArg},
+		examples:     []string{"release 2"},
+		preserveCase: true,
+		undoable:     true,
+	},
+	"stats": {
+		name:        "stats",
+		description: "Show your catch success rate overall and per Pokemon, streaks and the most escaped species",
+		callback:    commandStats,
+		category:    "Catching",
+	},
+	"party": {
+		name:        "party",
+		description: "Manage your party of up to six caught Pokemon, or share it as a Showdown team",
+		callback:    commandParty,
+		category:    "Your team",
+		subcommands: []subcommand{
+			{usage: "[list]", description: "Show your party"},
+			{usage: "add <catch-id|nickname|species>", description: "Add a Pokemon (a species picks the first one not in the party)"},
+			{usage: "remove <catch-id|nickname|species>", description: "Take a Pokemon out of the party"},
+			{usage: "swap <slot> <slot>", description: "Reorder two party slots (1-6)"},
+			{usage: "export showdown [file]", description: "Print the party as a Pokemon Showdown team, or write it to a file"},
+			{usage: "import showdown <file>", description: "Add a Showdown team to your party, validating species and moves"},
+		},
+		examples:     []string{"party add sparky", "party swap 1 2", "party export showdown ~/team.txt"},
+		preserveCase: true,
+	},
+	"box": {
+		name:        "box",
+		description: "Organize caught Pokemon into named PC boxes (up to 30 each)",
+		callback:    commandBox,
+		category:    "Your team",
+		subcommands: []subcommand{
+			{usage: "[list [box]]", description: "Show every box, or the Pokemon in one"},
+			{usage: "create <box>", description: "Create an empty box"},
+			{usage: "delete <box>", description: "Delete a box (its Pokemon stay in your Pokedex)"},
+			{usage: "move <catch-id|nickname|species> <box>", description: "Move a Pokemon into a box, taking it out of the party"},
+		},
+		examples:     []string{"box create Electric", "box move pikachu Electric"},
+		preserveCase: true,
+	},
+	"export": {
+		name:        "export",
+		description: "Export your Pokedex (name, id, types, stats, count) to a file",
+		callback:    commandExport,
+		category:    "Sharing",
+		usage:       "[--format csv|json] <file>",
+		args: []argSpec{
+			{name: "--format csv|json", description: "Defaults to the file extension"},
+		},
+		examples:     []string{"export ~/pokedex.csv", "export --format json ~/pokedex.txt"},
+		preserveCase: true,
+	},
+	"import": {
+		name:        "import",
+		description: "Import a Pokedex file, adding to your counts or replacing them with --replace",
+		callback:    commandImport,
+		category:    "Sharing",
+		usage:       "[--format csv|json] [--replace] <file>",
+		args: []argSpec{
+			{name: "--format csv|json", description: "Defaults to the file extension"},
+			{name: "--replace", description: "Replace your catches of each imported species instead of adding to them"},
+		},
+		examples:     []string{"import ~/pokedex.json", "import --replace ~/pokedex.csv"},
+		preserveCase: true,
+		undoable:     true,
+	},
+	"profile": {
+		name:        "profile",
+		description: "Manage trainer profiles, each with its own Pokedex, party, boxes and history",
+		callback:    commandProfile,
+		category:    "Settings",
+		subcommands: []subcommand{
+			{usage: "[list]", description: "Show every profile, marking the active one"},
+			{usage: "new <name>", description: "Create a profile and switch to it"},
+			{usage: "switch <name>", description: "Switch to another profile"},
+			{usage: "delete <name>", description: "Delete a profile other than the active one"},
+		},
+		examples: []string{"profile new misty", "profile switch default"},
+	},
+	"config": {
+		name:        "config",
+		description: "Show or change settings in ~/.config/pokedexcli/config.toml (env vars and flags override them)",
+		callback:    commandConfig,
+		category:    "Settings",
+		subcommands: []subcommand{
+			{usage: "[list]", description: "Show every setting and where its value comes from"},
+			{usage: "get <setting>", description: "Show one setting"},
+			{usage: "set <setting> <value>", description: "Save a setting to the config file (quote the value to keep its spaces)"},
+		},
+		examples:     []string{"config set output_format json", `config set prompt "Dex {profile} > "`},
+		preserveCase: true,
+	},
+	"theme": {
+		name:        "theme",
+		description: "List color themes or switch to another one (saved in your config file)",
+		callback:    commandTheme,
+		category:    "Settings",
+		usage:       "[name]",
+		examples:    []string{"theme pastel"},
+	},
+	"undo": {
+		name:        "undo",
+		description: "Revert the last catch, release, rename, import or clear (up to 20 steps this session)",
+		callback:    commandUndo,
+		category:    "Session",
+	},
+	"redo": {
+		name:        "redo",
+		description: "Reapply the last undone change",
+		callback:    commandRedo,
+		category:    "Session",
+	},
+	"clear": {
+		name:        "clear",
+		description: "Clear your caught Pokemon, party and boxes (and the API cache)",
+		callback:    commandClear,
+		category:    "Session",
+		undoable:    true,
+	},
+}
+
+// help reads the registry, so it's added here to avoid an initialization cycle
+func init() {
+	commands["help"] = cliCommand{
+		name:        "help",
+		description: "Show every command, or details and examples for one",
+		callback:    commandHelp,
+		category:    "Session",
+		usage:       "[command]",
+		examples:    []string{"help", "help party"},
+	}
+}
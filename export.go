@@ -0,0 +1,234 @@
+package main
+
+// Exports the Pokedex (one row per species) for sharing or spreadsheets, and
+// imports such files back, validating every name against PokeAPI first
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+// A count is how many times a species was caught; anything past this is a
+// broken or hand-edited file rather than a real Pokedex
+const maxImportCount = 999
+
+type exportEntry struct {
+	Name  string         `json:"name"`
+	ID    int            `json:"id"`
+	Types []string       `json:"types"`
+	Stats map[string]int `json:"stats"`
+	Count int            `json:"count"`
+}
+
+func commandExport(cfg *config, args ...string) error {
+	positional, flags := splitFlags(args, "format")
+	path := firstArg(positional)
+	if path == "" {
+		fmt.Printf("Please provide the file to export to\n\n")
+		return nil
+	}
+	path = expandHome(path)
+
+	format, err := fileFormat(path, flags["format"])
+	if err != nil {
+		return err
+	}
+
+	var entries []exportEntry
+	for _, name := range cfg.caughtSpeciesNames() {
+		pokemon := cfg.caughtPokemon[name]
+		stats := make(map[string]int, len(pokemon.Stats))
+		for _, stat := range pokemon.Stats {
+			stats[stat.Stat.Name] = stat.BaseStat
+		}
+		entries = append(entries, exportEntry{
+			Name:  pokemon.Name,
+			ID:    pokemon.ID,
+			Types: pokemon.TypeNames(),
+			Stats: stats,
+			Count: cfg.timesCaught(name),
+		})
+	}
+
+	var data []byte
+	switch format {
+	case "json":
+		data, err = json.MarshalIndent(entries, "", "  ")
+	case "csv":
+		data, err = encodeCSV(entries)
+	}
+	if err != nil {
+		return fmt.Errorf("Error encoding %s: %w", format, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("Error writing %s: %w", path, err)
+	}
+
+	fmt.Printf("Exported %d Pokemon to %s\n\n", len(entries), path)
+	return nil
+}
+
+func commandImport(cfg *config, args ...string) error {
+	positional, flags := splitFlags(args, "format")
+	path := firstArg(positional)
+	if path == "" {
+		fmt.Printf("Please provide the file to import\n\n")
+		return nil
+	}
+	path = expandHome(path)
+
+	format, err := fileFormat(path, flags["format"])
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+
+	var entries []exportEntry
+	switch format {
+	case "json":
+		err = json.Unmarshal(raw, &entries)
+	case "csv":
+		entries, err = decodeCSV(raw)
+	}
+	if err != nil {
+		return fmt.Errorf("Error decoding %s: %w", path, err)
+	}
+
+	// Validate everything before touching the Pokedex so a bad file changes nothing
+	validated := make([]pokeapi.Pokemon, 0, len(entries))
+	var invalid []string
+	for _, entry := range entries {
+		if entry.Count < 1 || entry.Count > maxImportCount {
+			return fmt.Errorf("Invalid count %d for '%s', expected 1 to %d", entry.Count, entry.Name, maxImportCount)
+		}
+		pokemon, err := cfg.pokeClient.GetPokemonInfo(strings.ToLower(entry.Name))
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			invalid = append(invalid, entry.Name)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Error fetching Pokemon '%s': %w", entry.Name, err)
+		}
+		validated = append(validated, pokemon)
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("Nothing imported, unknown Pokemon: %s", strings.Join(invalid, ", "))
+	}
+
+	replace := flags["replace"] != ""
+	added := 0
+	for i, pokemon := range validated {
+		if replace {
+			for _, record := range cfg.catchesOf(pokemon.Name) {
+				cfg.releaseCatch(record.ID)
+			}
+		}
+		for range entries[i].Count {
+			cfg.recordCatch(pokemon)
+			// Imported catches weren't caught anywhere; note where they came from instead
+			cfg.catches[len(cfg.catches)-1].Location = "import:" + filepath.Base(path)
+			added++
+		}
+		cfg.markSeen(pokemon.Species.Name)
+	}
+	if err := cfg.save(); err != nil {
+		return err
+	}
+
+	mode := "Merged"
+	if replace {
+		mode = "Replaced"
+	}
+	fmt.Printf("%s %d species (%d catches) from %s\n\n", mode, len(validated), added, path)
+	return nil
+}
+
+// An explicit --format wins, otherwise the file extension decides
+func fileFormat(path, explicit string) (string, error) {
+	format := strings.ToLower(explicit)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	if format != "csv" && format != "json" {
+		return "", fmt.Errorf("Unknown format '%s', expected csv or json (use --format or a .csv/.json file name)", format)
+	}
+	return format, nil
+}
+
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+func encodeCSV(entries []exportEntry) ([]byte, error) {
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+
+	header := append([]string{"name", "id", "types"}, statNames...)
+	header = append(header, "count")
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		row := []string{entry.Name, strconv.Itoa(entry.ID), strings.Join(entry.Types, "/")}
+		for _, stat := range statNames {
+			row = append(row, strconv.Itoa(entry.Stats[stat]))
+		}
+		row = append(row, strconv.Itoa(entry.Count))
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return []byte(sb.String()), w.Error()
+}
+
+// Only name and count are needed to import; the other columns are informational
+func decodeCSV(raw []byte) ([]exportEntry, error) {
+	records, err := csv.NewReader(strings.NewReader(string(raw))).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	nameCol, hasName := columns["name"]
+	countCol, hasCount := columns["count"]
+	if !hasName {
+		return nil, fmt.Errorf("missing 'name' column")
+	}
+
+	entries := make([]exportEntry, 0, len(records)-1)
+	for line, record := range records[1:] {
+		entry := exportEntry{Name: strings.TrimSpace(record[nameCol]), Count: 1}
+		if hasCount {
+			count, err := strconv.Atoi(strings.TrimSpace(record[countCol]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid count: %w", line+2, err)
+			}
+			entry.Count = count
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestExportImportRoundTrip(t *testing.T) {
+	dex := map[string]pokeapi.Pokemon{
+		"pikachu": {
+			ID:    25,
+			Name:  "pikachu",
+			Types: []pokeapi.PokemonType{{Type: pokeapi.NamedAPIResource{Name: "electric"}}},
+			Stats: []pokeapi.PokemonStat{{Stat: pokeapi.NamedAPIResource{Name: "speed"}, BaseStat: 90}},
+		},
+		"bulbasaur": {ID: 1, Name: "bulbasaur"},
+	}
+	client := &mockClient{
+		getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+			pokemon, ok := dex[pokemonName]
+			if !ok {
+				return pokeapi.Pokemon{}, pokeapi.ErrNotFound
+			}
+			return pokemon, nil
+		},
+	}
+
+	for _, format := range []string{"csv", "json"} {
+		t.Run(format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pokedex."+format)
+
+			source := &config{pokeClient: client, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+			source.recordCatch(dex["pikachu"])
+			source.recordCatch(dex["pikachu"])
+			source.recordCatch(dex["bulbasaur"])
+			if err := commandExport(source, path); err != nil {
+				t.Fatalf("Unexpected error exporting: %v", err)
+			}
+
+			target := &config{pokeClient: client, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+			target.recordCatch(dex["pikachu"])
+			if err := commandImport(target, path); err != nil {
+				t.Fatalf("Unexpected error importing: %v", err)
+			}
+			if target.timesCaught("pikachu") != 3 || target.timesCaught("bulbasaur") != 1 {
+				t.Errorf("Expected merged counts 3 and 1, got %d and %d", target.timesCaught("pikachu"), target.timesCaught("bulbasaur"))
+			}
+
+			if err := commandImport(target, path, "--replace"); err != nil {
+				t.Fatalf("Unexpected error importing: %v", err)
+			}
+			if target.timesCaught("pikachu") != 2 || target.timesCaught("bulbasaur") != 1 {
+				t.Errorf("Expected replaced counts 2 and 1, got %d and %d", target.timesCaught("pikachu"), target.timesCaught("bulbasaur"))
+			}
+		})
+	}
+}
+
+func TestImportRejectsUnknownPokemon(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.csv")
+	source := &config{caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	source.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+	source.recordCatch(pokeapi.Pokemon{ID: 9999, Name: "missingno"})
+	if err := commandExport(source, path); err != nil {
+		t.Fatalf("Unexpected error exporting: %v", err)
+	}
+
+	target := &config{
+		pokeClient: &mockClient{
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				if pokemonName == "missingno" {
+					return pokeapi.Pokemon{}, pokeapi.ErrNotFound
+				}
+				return pokeapi.Pokemon{ID: 25, Name: pokemonName}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+	if err := commandImport(target, path); err == nil {
+		t.Error("Expected an import with unknown Pokemon to fail")
+	}
+	if len(target.catches) != 0 {
+		t.Errorf("Expected nothing imported, got %d catches", len(target.catches))
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		mockError     error
+		expectedError string
+	}{
+		{name: "zero count", file: `[{"name": "pikachu", "count": 0}]`, expectedError: "Invalid count 0"},
+		{name: "negative count", file: `[{"name": "pikachu", "count": -2}]`, expectedError: "Invalid count -2"},
+		{name: "count above the cap", file: `[{"name": "pikachu", "count": 1000000}]`, expectedError: "Invalid count 1000000"},
+		{name: "API failure", file: `[{"name": "pikachu", "count": 1}]`, mockError: errors.New("Received status: 500"), expectedError: "Error fetching Pokemon 'pikachu'"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pokedex.json")
+			if err := os.WriteFile(path, []byte(test.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config{
+				pokeClient: &mockClient{
+					getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+						return pokeapi.Pokemon{ID: 25, Name: pokemonName}, test.mockError
+					},
+				},
+				caughtPokemon: make(map[string]pokeapi.Pokemon),
+			}
+
+			err := commandImport(cfg, path)
+			if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+				t.Errorf("Expected an error containing %q, got %v", test.expectedError, err)
+			}
+			if len(cfg.catches) != 0 {
+				t.Errorf("Expected nothing imported, got %d catches", len(cfg.catches))
+			}
+		})
+	}
+}
+
+func TestFileFormat(t *testing.T) {
+	tests := []struct {
+		path          string
+		explicit      string
+		expected      string
+		expectedError bool
+	}{
+		{path: "dex.csv", expected: "csv"},
+		{path: "dex.JSON", expected: "json"},
+		{path: "dex.txt", explicit: "json", expected: "json"},
+		{path: "dex.txt", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path+test.explicit, func(t *testing.T) {
+			format, err := fileFormat(test.path, test.explicit)
+			if test.expectedError != (err != nil) {
+				t.Fatalf("Unexpected error state: %v", err)
+			}
+			if format != test.expected {
+				t.Errorf("Expected %s, got %s", test.expected, format)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+// Startup flags. Most of them are shortcuts for settings (see settings.go)
+// and win over both the config file and the environment.
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"strings"
+)
+
+// Set at build time with -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+type settingOverride struct {
+	key   string
+	value string
+}
+
+type startupOptions struct {
+	profile     string
+	configPath  string
+	overrides   []settingOverride
+	seed        int64
+	seedSet     bool
+	showVersion bool
+}
+
+// Flags that set a setting of the same meaning
+var settingFlags = map[string]string{
+	"timeout":      "timeout",
+	"cache-ttl":    "cache_ttl",
+	"api-url":      "api_url",
+	"history-file": "history_file",
+}
+
+func parseFlags(args []string, output io.Writer) (startupOptions, error) {
+	var opts startupOptions
+	fs := flag.NewFlagSet("pokedexcli", flag.ContinueOnError)
+	fs.SetOutput(output)
+
+	fs.StringVar(&opts.profile, "profile", defaultProfile, "trainer profile to load")
+	fs.StringVar(&opts.configPath, "config", defaultSettingsPath(), "config file to read settings from")
+	noCache := fs.Bool("no-cache", false, "don't cache PokeAPI responses")
+	fs.String("timeout", "", "HTTP timeout for PokeAPI requests (e.g. 5s)")
+	fs.String("cache-ttl", "", "how long cached responses stay fresh (e.g. 5m)")
+	fs.String("api-url", "", "base URL of the PokeAPI instance to use")
+	fs.String("history-file", "", "command history file shared by every profile")
+	fs.Int64Var(&opts.seed, "seed", 0, "seed for catch rolls, levels, IVs and natures (for reproducible sessions)")
+	fs.BoolVar(&opts.showVersion, "version", false, "print the version and exit")
+	fs.Func("set", "override any setting for this session (key=value, repeatable)", func(value string) error {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok {
+			return fmt.Errorf("expected key=value")
+		}
+		opts.overrides = append(opts.overrides, settingOverride{key: strings.TrimSpace(key), value: strings.TrimSpace(val)})
+		return nil
+	})
+
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	// Only flags given on the command line override settings; named flags are
+	// applied after --set, so they win when both name the same setting
+	fs.Visit(func(f *flag.Flag) {
+		switch {
+		case f.Name == "seed":
+			opts.seedSet = true
+		case f.Name == "no-cache" && *noCache:
+			opts.overrides = append(opts.overrides, settingOverride{key: "cache", value: "false"})
+		case settingFlags[f.Name] != "":
+			opts.overrides = append(opts.overrides, settingOverride{key: settingFlags[f.Name], value: f.Value.String()})
+		}
+	})
+	return opts, nil
+}
+
+// Prefers the version stamped at build time, then the module version when
+// installed with 'go install'
+func versionString() string {
+	if version == "dev" {
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+			return info.Main.Version
+		}
+	}
+	return version
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"io"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		name              string
+		args              []string
+		expectedOverrides []settingOverride
+		expectedProfile   string
+		expectedSeed      bool
+		expectedError     bool
+	}{
+		{
+			name:            "defaults",
+			expectedProfile: defaultProfile,
+		},
+		{
+			name: "setting flags",
+			args: []string{"--no-cache", "--timeout", "2s", "--cache-ttl=1m", "--api-url", "http://localhost:8000/api/v2", "--profile", "misty"},
+			expectedOverrides: []settingOverride{
+				{key: "api_url", value: "http://localhost:8000/api/v2"},
+				{key: "cache_ttl", value: "1m"},
+				{key: "cache", value: "false"},
+				{key: "timeout", value: "2s"},
+			},
+			expectedProfile: "misty",
+		},
+		{
+			name:              "named flags win over --set",
+			args:              []string{"--timeout", "2s", "--set", "timeout=9s"},
+			expectedOverrides: []settingOverride{{key: "timeout", value: "9s"}, {key: "timeout", value: "2s"}},
+			expectedProfile:   defaultProfile,
+		},
+		{
+			name:            "seed",
+			args:            []string{"--seed", "0"},
+			expectedProfile: defaultProfile,
+			expectedSeed:    true,
+		},
+		{name: "unknown flag", args: []string{"--turbo"}, expectedError: true},
+		{name: "bad seed", args: []string{"--seed", "pikachu"}, expectedError: true},
+		{name: "stray argument", args: []string{"pikachu"}, expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts, err := parseFlags(test.args, io.Discard)
+			if test.expectedError {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(opts.overrides, test.expectedOverrides) {
+				t.Errorf("Expected overrides %v, got %v", test.expectedOverrides, opts.overrides)
+			}
+			if opts.profile != test.expectedProfile {
+				t.Errorf("Expected profile %s, got %s", test.expectedProfile, opts.profile)
+			}
+			if opts.seedSet != test.expectedSeed {
+				t.Errorf("Expected seedSet %v, got %v", test.expectedSeed, opts.seedSet)
+			}
+		})
+	}
+}
+
+func TestSeedMakesCatchesReproducible(t *testing.T) {
+	pikachu := pokeapi.Pokemon{
+		ID:    25,
+		Name:  "pikachu",
+		Stats: []pokeapi.PokemonStat{{Stat: pokeapi.NamedAPIResource{Name: "speed"}}},
+	}
+	catch := func() catchRecord {
+		cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon), rng: rand.New(rand.NewSource(42))}
+		return cfg.recordCatch(pikachu)
+	}
+
+	first, second := catch(), catch()
+	if first.Level != second.Level || first.Nature != second.Nature || first.IVs["speed"] != second.IVs["speed"] {
+		t.Errorf("Expected the same seed to give the same catch, got %+v and %+v", first, second)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Help shows commands after the same prompt the user types them at
+func commandHelp(cfg *config, args ...string) error {
+	prompt := cfg.settings.prompt(cfg.profile)
+	name := firstArg(args)
+	if name == "" {
+		fmt.Print(helpOverview(cfg.out, prompt))
+		return nil
+	}
+
+	command, ok := commands[name]
+	if !ok {
+		fmt.Printf("There is no command called '%s'.%s\n\n", name, didYouMean(suggestNames(name, commandNames(), 3)))
+		return nil
+	}
+	fmt.Print(commandDetails(cfg.out, prompt, command))
+	return nil
+}
+
+func commandNames() []string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// The ways a command can be typed: one line per subcommand, or just its usage
+func usageLines(command cliCommand) []string {
+	if len(command.subcommands) == 0 {
+		return []string{strings.TrimSpace(command.name + " " + command.usage)}
+	}
+	lines := make([]string, 0, len(command.subcommands))
+	for _, sub := range command.subcommands {
+		lines = append(lines, command.name+" "+sub.usage)
+	}
+	return lines
+}
+
+// Every command grouped by category, in the order of commandCategories and
+// alphabetically within each one
+func helpOverview(out *renderer, prompt string) string {
+	var sb strings.Builder
+	sb.WriteString("Usage:\n\n")
+	for _, category := range commandCategories {
+		sb.WriteString(out.heading(category) + "\n")
+		for _, name := range commandNames() {
+			command := commands[name]
+			if command.category != category {
+				continue
+			}
+			for _, line := range usageLines(command) {
+				sb.WriteString("\t" + prompt + line + "\n")
+			}
+			sb.WriteString("\t\t" + command.description + "\n\n")
+		}
+	}
+	sb.WriteString("\t" + prompt + "press the up or down arrow\n" +
+		"\t\tBrowse through previously typed commands\n\n" +
+		"Enter 'help <command>' for details and examples.\n\n")
+	return sb.String()
+}
+
+func commandDetails(out *renderer, prompt string, command cliCommand) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s - %s\n\n", out.heading(command.name), command.description)
+
+	sb.WriteString(out.heading("Usage:") + "\n")
+	for _, line := range usageLines(command) {
+		sb.WriteString("\t" + prompt + line + "\n")
+	}
+
+	if len(command.subcommands) > 0 {
+		rows := make([][]string, 0, len(command.subcommands))
+		for _, sub := range command.subcommands {
+			rows = append(rows, []string{"\t" + sub.usage, sub.description})
+		}
+		sb.WriteString("\n" + out.heading("Subcommands:") + "\n" + formatTable(rows))
+	}
+
+	if len(command.args) > 0 {
+		rows := make([][]string, 0, len(command.args))
+		for _, arg := range command.args {
+			rows = append(rows, []string{"\t" + arg.name, arg.description})
+		}
+		sb.WriteString("\n" + out.heading("Arguments:") + "\n" + formatTable(rows))
+	}
+
+	if len(command.examples) > 0 {
+		sb.WriteString("\n" + out.heading("Examples:") + "\n")
+		for _, example := range command.examples {
+			sb.WriteString("\t" + prompt + example + "\n")
+		}
+	}
+
+	var notes []string
+	if command.undoable {
+		notes = append(notes, "Can be reverted with 'undo'.")
+	}
+	if command.preserveCase {
+		notes = append(notes, "Arguments keep their case (nicknames, box names, file paths).")
+	}
+	if len(notes) > 0 {
+		sb.WriteString("\n" + strings.Join(notes, " ") + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// The default prompt for the "ash" profile
+const helpPrompt = "Pokedex [ash] > "
+
+func TestRegistryIsComplete(t *testing.T) {
+	for key, command := range commands {
+		if command.name != key {
+			t.Errorf("Command registered as '%s' is named '%s'", key, command.name)
+		}
+		if command.description == "" || command.callback == nil {
+			t.Errorf("Command '%s' needs a description and a callback", key)
+		}
+		if !slices.Contains(commandCategories, command.category) {
+			t.Errorf("Command '%s' has unknown category '%s' and would be missing from help", key, command.category)
+		}
+		for _, example := range command.examples {
+			if strings.Fields(example)[0] != key {
+				t.Errorf("Example '%s' of '%s' runs another command", example, key)
+			}
+		}
+	}
+}
+
+func TestHelpOverview(t *testing.T) {
+	overview := helpOverview(nil, helpPrompt)
+	for name, command := range commands {
+		for _, line := range usageLines(command) {
+			if !strings.Contains(overview, helpPrompt+line+"\n") {
+				t.Errorf("Expected help to show '%s' for %s", line, name)
+			}
+		}
+	}
+	if overview != helpOverview(nil, helpPrompt) {
+		t.Error("Expected help to come out the same every time")
+	}
+
+	// Every usage line (or run of lines for one command) is followed by a description
+	lines := strings.Split(overview, "\n")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, "\t"+helpPrompt) {
+			continue
+		}
+		next := i + 1
+		for next < len(lines) && strings.HasPrefix(lines[next], "\t"+helpPrompt) {
+			next++
+		}
+		if next == len(lines) || !strings.HasPrefix(lines[next], "\t\t") || strings.TrimSpace(lines[next]) == "" {
+			t.Errorf("Expected a description under '%s'", strings.TrimSpace(line))
+		}
+	}
+
+	// Categories come in their declared order
+	last := -1
+	for _, category := range commandCategories {
+		i := strings.Index(overview, "\n"+category+"\n")
+		if i == -1 {
+			t.Errorf("Expected a %s section", category)
+		}
+		if i < last {
+			t.Errorf("Expected category %s after the previous one", category)
+		}
+		last = i
+	}
+}
+
+func TestHelpUsesConfiguredPrompt(t *testing.T) {
+	cfg := &config{profile: "ash"}
+	if overview := captureStdout(t, func() { commandHelp(cfg) }); !strings.Contains(overview, "\t"+helpPrompt+"catch <pokemon|dex-number>\n") {
+		t.Errorf("Expected help to use the default prompt, got:\n%s", overview)
+	}
+
+	if err := cfg.settings.set("prompt", "{profile}$ ", sourceFlag); err != nil {
+		t.Fatal(err)
+	}
+	if details := captureStdout(t, func() { commandHelp(cfg, "catch") }); !strings.Contains(details, "\tash$ catch pikachu\n") {
+		t.Errorf("Expected help to use the configured prompt, got:\n%s", details)
+	}
+}
+
+func TestCommandDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected []string
+	}{
+		{
+			name: "party",
+			expected: []string{
+				"Subcommands:", "\tswap <slot> <slot>  ", helpPrompt + "party import showdown <file>\n",
+				"Examples:", helpPrompt + "party add sparky\n",
+			},
+		},
+		{
+			name:     "inspect",
+			expected: []string{"Arguments:", "--refresh", helpPrompt + "inspect pikachu\n"},
+		},
+		{
+			name:     "release",
+			expected: []string{"Can be reverted with 'undo'.", "<catch-id|nickname>"},
+		},
+		{
+			name:     "stats",
+			expected: []string{"stats - ", helpPrompt + "stats\n"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			details := commandDetails(nil, helpPrompt, commands[test.name])
+			for _, expected := range test.expected {
+				if !strings.Contains(details, expected) {
+					t.Errorf("Expected %q in:\n%s", expected, details)
+				}
+			}
+		})
+	}
+}
@@ -104,3 +104,36 @@ func TestGetExpired(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedPokemonSharedByNameAndID(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	upstream := &countingClient{pokemon: Pokemon{ID: 25, Name: "pikachu"}}
+	client := NewCachedClient(upstream, NewCache(ctx, time.Minute), time.Minute)
+
+	for _, identifier := range []string{"pikachu", "25", "Pikachu", "#025"} {
+		pokemon, err := client.GetPokemonInfo(identifier)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", identifier, err)
+		}
+		if pokemon.Name != "pikachu" {
+			t.Errorf("Expected pikachu for %s, got %s", identifier, pokemon.Name)
+		}
+	}
+
+	if upstream.calls != 1 {
+		t.Errorf("Expected 1 upstream call, got %d", upstream.calls)
+	}
+}
+
+type countingClient struct {
+	PokeAPIClient
+	pokemon Pokemon
+	calls   int
+}
+
+func (c *countingClient) GetPokemonInfo(pokemonName string) (Pokemon, error) {
+	c.calls++
+	return c.pokemon, nil
+}
@@ -3,7 +3,9 @@ package pokeapi
 // Wraps any client implementation and add caching
 
 import (
+	"context"
 	"encoding/json"
+	"strconv"
 	"time"
 )
 
@@ -47,23 +49,32 @@ func (c *CachedClient) GetLocationAreas(pageURL *string) (LocationAreaResponse,
 
 }
 
+// Pokemon are cached once under their canonical name; dex numbers are stored
+// as aliases pointing at that name so "25" and "pikachu" share an entry
 func (c *CachedClient) GetPokemonInfo(pokemonName string) (Pokemon, error) {
-	key := "pokemon:" + pokemonName
+	identifier := NormalizeIdentifier(pokemonName)
+	name := identifier
+	if _, isID := ParseID(identifier); isID {
+		if alias, found := c.cache.Get("pokemon-id:" + identifier); found {
+			name = string(alias)
+		}
+	}
 
-	if cached, found := c.cache.Get(key); found {
+	if cached, found := c.cache.Get("pokemon:" + name); found {
 		var resp Pokemon
 		if err := json.Unmarshal(cached, &resp); err == nil {
 			return resp, nil
 		}
 	}
 
-	resp, err := c.client.GetPokemonInfo(pokemonName)
+	resp, err := c.client.GetPokemonInfo(identifier)
 	if err != nil {
 		return Pokemon{}, err
 	}
 
 	if data, err := json.Marshal(resp); err == nil {
-		c.cache.Set(key, data, c.ttl)
+		c.cache.Set("pokemon:"+resp.Name, data, c.ttl)
+		c.cache.Set("pokemon-id:"+strconv.Itoa(resp.ID), []byte(resp.Name), c.ttl)
 	}
 
 	return resp, nil
@@ -95,6 +106,158 @@ func (c *CachedClient) GetPokemonInLocationArea(areaURL *string) (PokemonInLocat
 	return resp, nil
 }
 
+func (c *CachedClient) ListResources(ctx context.Context, kind ResourceKind, page *string) (NamedAPIResourceList, error) {
+	key := "list:" + string(kind) + ":default"
+	if page != nil {
+		key = "list:" + string(kind) + ":" + *page
+	}
+
+	if cached, found := c.cache.Get(key); found {
+		var resp NamedAPIResourceList
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return resp, nil
+		}
+	}
+
+	resp, err := c.client.ListResources(ctx, kind, page)
+	if err != nil {
+		return NamedAPIResourceList{}, err
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return resp, nil
+}
+
+func (c *CachedClient) GetResourceNames(ctx context.Context, kind ResourceKind) ([]string, error) {
+	key := "names:" + string(kind)
+
+	if cached, found := c.cache.Get(key); found {
+		var names []string
+		if err := json.Unmarshal(cached, &names); err == nil {
+			return names, nil
+		}
+	}
+
+	names, err := c.client.GetResourceNames(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	if data, err := json.Marshal(names); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return names, nil
+}
+
+func (c *CachedClient) GetGeneration(ctx context.Context, name string) (Generation, error) {
+	key := "generation:" + NormalizeIdentifier(name)
+
+	if cached, found := c.cache.Get(key); found {
+		var resp Generation
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return resp, nil
+		}
+	}
+
+	resp, err := c.client.GetGeneration(ctx, name)
+	if err != nil {
+		return Generation{}, err
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return resp, nil
+}
+
+func (c *CachedClient) GetType(ctx context.Context, name string) (Type, error) {
+	key := "type:" + NormalizeIdentifier(name)
+
+	if cached, found := c.cache.Get(key); found {
+		var resp Type
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return resp, nil
+		}
+	}
+
+	resp, err := c.client.GetType(ctx, name)
+	if err != nil {
+		return Type{}, err
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return resp, nil
+}
+
+// Sprites are already bytes, so they go into the cache as-is
+func (c *CachedClient) GetSprite(ctx context.Context, spriteURL string) ([]byte, error) {
+	key := "sprite:" + spriteURL
+
+	if cached, found := c.cache.Get(key); found {
+		return cached, nil
+	}
+
+	data, err := c.client.GetSprite(ctx, spriteURL)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, data, c.ttl)
+
+	return data, nil
+}
+
+// Not cached here: the CLI saves the aggregate to disk, which outlives both
+// this cache's TTL and the session
+func (c *CachedClient) GetStatAggregate(ctx context.Context) (StatAggregate, error) {
+	return c.client.GetStatAggregate(ctx)
+}
+
+func (c *CachedClient) GetMove(ctx context.Context, name string) (Move, error) {
+	key := "move:" + NormalizeIdentifier(name)
+
+	if cached, found := c.cache.Get(key); found {
+		var resp Move
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return resp, nil
+		}
+	}
+
+	resp, err := c.client.GetMove(ctx, name)
+	if err != nil {
+		return Move{}, err
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return resp, nil
+}
+
+func (c *CachedClient) GetAbility(ctx context.Context, name string) (Ability, error) {
+	key := "ability:" + NormalizeIdentifier(name)
+
+	if cached, found := c.cache.Get(key); found {
+		var resp Ability
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return resp, nil
+		}
+	}
+
+	resp, err := c.client.GetAbility(ctx, name)
+	if err != nil {
+		return Ability{}, err
+	}
+	if data, err := json.Marshal(resp); err == nil {
+		c.cache.Set(key, data, c.ttl)
+	}
+
+	return resp, nil
+}
+
 func (c *CachedClient) Clear() {
 	c.cache.Clear()
 }
@@ -3,22 +3,43 @@ package pokeapi
 // Makes HTTP requests ONLY (no caching logic)
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
+var ErrNotFound = errors.New("resource not found")
+
 type Client struct {
 	httpClient *http.Client
 	baseURL    string
 }
 
-func NewClient(timeout time.Duration) *Client {
-	return &Client{
+const DefaultBaseURL = "https://pokeapi.co/api/v2"
+
+type ClientOption func(*Client)
+
+// WithBaseURL points the client at another PokeAPI instance, such as a local mirror
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+func NewClient(timeout time.Duration, opts ...ClientOption) *Client {
+	c := &Client{
 		httpClient: &http.Client{Timeout: timeout},
-		baseURL:    "https://pokeapi.co/api/v2",
+		baseURL:    DefaultBaseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c
 }
 
 func (c *Client) GetLocationAreas(pageURL *string) (LocationAreaResponse, error) {
@@ -46,16 +67,28 @@ func (c *Client) GetLocationAreas(pageURL *string) (LocationAreaResponse, error)
 }
 
 func (c *Client) GetPokemonInfo(pokemonName string) (Pokemon, error) {
-	url := c.baseURL + "/pokemon/" + pokemonName + "/"
+	return c.getPokemon(context.Background(), pokemonName)
+}
 
-	resp, err := c.httpClient.Get(url)
+// Like GetPokemonInfo, but the request is abandoned as soon as ctx is done
+func (c *Client) getPokemon(ctx context.Context, pokemonName string) (Pokemon, error) {
+	url := c.baseURL + "/pokemon/" + NormalizeIdentifier(pokemonName) + "/"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Pokemon{}, fmt.Errorf("Error creating request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return Pokemon{}, fmt.Errorf("Error fetching Pokemon: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound {
+		return Pokemon{}, fmt.Errorf("Pokemon '%s': %w", pokemonName, ErrNotFound)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return Pokemon{}, fmt.Errorf("Received status: %w", err)
+		return Pokemon{}, fmt.Errorf("Received status: %s", resp.Status)
 	}
 
 	var pokemon Pokemon
@@ -89,4 +122,123 @@ func (c *Client) GetPokemonInLocationArea(areaName *string) (PokemonInLocationRe
 
 }
 
+func (c *Client) ListResources(ctx context.Context, kind ResourceKind, page *string) (NamedAPIResourceList, error) {
+	url := c.baseURL + "/" + string(kind)
+	if page != nil {
+		url = *page
+	}
+
+	var list NamedAPIResourceList
+	if err := c.getJSON(ctx, url, &list); err != nil {
+		return NamedAPIResourceList{}, err
+	}
+	return list, nil
+}
+
+func (c *Client) GetResourceNames(ctx context.Context, kind ResourceKind) ([]string, error) {
+	url := fmt.Sprintf("%s/%s?limit=100000", c.baseURL, kind)
+
+	var list NamedAPIResourceList
+	if err := c.getJSON(ctx, url, &list); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list.Results))
+	for _, resource := range list.Results {
+		names = append(names, resource.Name)
+	}
+	return names, nil
+}
+
+func (c *Client) GetGeneration(ctx context.Context, name string) (Generation, error) {
+	url := c.baseURL + "/generation/" + NormalizeIdentifier(name) + "/"
+
+	var generation Generation
+	if err := c.getJSON(ctx, url, &generation); err != nil {
+		return Generation{}, err
+	}
+	return generation, nil
+}
+
+func (c *Client) GetType(ctx context.Context, name string) (Type, error) {
+	url := c.baseURL + "/type/" + NormalizeIdentifier(name) + "/"
+
+	var t Type
+	if err := c.getJSON(ctx, url, &t); err != nil {
+		return Type{}, err
+	}
+	return t, nil
+}
+
+func (c *Client) GetSprite(ctx context.Context, spriteURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, spriteURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error fetching sprite: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", spriteURL, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Received status: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading sprite: %w", err)
+	}
+	return data, nil
+}
+
+func (c *Client) GetMove(ctx context.Context, name string) (Move, error) {
+	url := c.baseURL + "/move/" + NormalizeIdentifier(name) + "/"
+
+	var move Move
+	if err := c.getJSON(ctx, url, &move); err != nil {
+		return Move{}, err
+	}
+	return move, nil
+}
+
+func (c *Client) GetAbility(ctx context.Context, name string) (Ability, error) {
+	url := c.baseURL + "/ability/" + NormalizeIdentifier(name) + "/"
+
+	var ability Ability
+	if err := c.getJSON(ctx, url, &ability); err != nil {
+		return Ability{}, err
+	}
+	return ability, nil
+}
+
 func (c *Client) Clear() {}
+
+func (c *Client) getJSON(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("Error creating request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Error making GET request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s: %w", url, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Received status: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return fmt.Errorf("Error decoding JSON: %w", err)
+	}
+	return nil
+}
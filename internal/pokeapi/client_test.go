@@ -0,0 +1,33 @@
+package pokeapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientWithBaseURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/pokemon/pikachu/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(time.Second, WithBaseURL(server.URL+"/api/v2/"))
+
+	pokemon, err := client.GetPokemonInfo("Pikachu")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pokemon.ID != 25 {
+		t.Errorf("Expected pikachu from the mirror, got %+v", pokemon)
+	}
+	if _, err := client.GetPokemonInfo("missingno"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package pokeapi
+
+// PokeAPI accepts either a name or a numeric ID wherever a resource is looked up
+
+import (
+	"strconv"
+	"strings"
+)
+
+// NormalizeIdentifier turns user input like "Pikachu", "#025" or "25" into the
+// form PokeAPI expects ("pikachu", "25")
+func NormalizeIdentifier(identifier string) string {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if id, ok := ParseID(identifier); ok {
+		return strconv.Itoa(id)
+	}
+	return identifier
+}
+
+// ParseID reports whether identifier is a national dex number rather than a name
+func ParseID(identifier string) (int, bool) {
+	id, err := strconv.Atoi(strings.TrimPrefix(identifier, "#"))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
+}
@@ -1,5 +1,7 @@
 package pokeapi
 
+import "context"
+
 // Define contract for interacting with PokeAPI
 // Both Client and CachedClient will implement
 
@@ -7,5 +9,13 @@ type PokeAPIClient interface {
 	GetLocationAreas(pageURL *string) (LocationAreaResponse, error)
 	GetPokemonInfo(pokemonName string) (Pokemon, error)
 	GetPokemonInLocationArea(areaName *string) (PokemonInLocationResponse, error)
+	ListResources(ctx context.Context, kind ResourceKind, page *string) (NamedAPIResourceList, error)
+	GetResourceNames(ctx context.Context, kind ResourceKind) ([]string, error)
+	GetGeneration(ctx context.Context, name string) (Generation, error)
+	GetType(ctx context.Context, name string) (Type, error)
+	GetSprite(ctx context.Context, spriteURL string) ([]byte, error)
+	GetStatAggregate(ctx context.Context) (StatAggregate, error)
+	GetMove(ctx context.Context, name string) (Move, error)
+	GetAbility(ctx context.Context, name string) (Ability, error)
 	Clear()
 }
@@ -0,0 +1,62 @@
+package pokeapi
+
+// Walks any NamedAPIResourceList endpoint page by page, remembering its own cursors
+
+import "errors"
+
+var (
+	ErrNoNextPage     = errors.New("no next page")
+	ErrNoPreviousPage = errors.New("no previous page")
+)
+
+type PageFetcher func(pageURL *string) (NamedAPIResourceList, error)
+
+type Paginator struct {
+	fetch    PageFetcher
+	next     *string
+	previous *string
+	started  bool
+}
+
+func NewPaginator(fetch PageFetcher) *Paginator {
+	return &Paginator{fetch: fetch}
+}
+
+func (p *Paginator) Next() (NamedAPIResourceList, error) {
+	if p.started && p.next == nil {
+		return NamedAPIResourceList{}, ErrNoNextPage
+	}
+	return p.load(p.next)
+}
+
+func (p *Paginator) Previous() (NamedAPIResourceList, error) {
+	if p.previous == nil {
+		return NamedAPIResourceList{}, ErrNoPreviousPage
+	}
+	return p.load(p.previous)
+}
+
+func (p *Paginator) NextURL() *string {
+	return p.next
+}
+
+func (p *Paginator) PreviousURL() *string {
+	return p.previous
+}
+
+func (p *Paginator) Reset() {
+	p.next = nil
+	p.previous = nil
+	p.started = false
+}
+
+func (p *Paginator) load(pageURL *string) (NamedAPIResourceList, error) {
+	page, err := p.fetch(pageURL)
+	if err != nil {
+		return NamedAPIResourceList{}, err
+	}
+	p.next = page.Next
+	p.previous = page.Previous
+	p.started = true
+	return page, nil
+}
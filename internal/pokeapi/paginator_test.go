@@ -0,0 +1,52 @@
+package pokeapi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPaginatorNavigation(t *testing.T) {
+	page1 := "page1"
+	page2 := "page2"
+	pages := map[string]NamedAPIResourceList{
+		page1: {Next: &page2, Results: []NamedAPIResource{{Name: "first"}}},
+		page2: {Previous: &page1, Results: []NamedAPIResource{{Name: "second"}}},
+	}
+
+	p := NewPaginator(func(pageURL *string) (NamedAPIResourceList, error) {
+		if pageURL == nil {
+			return pages[page1], nil
+		}
+		return pages[*pageURL], nil
+	})
+
+	if _, err := p.Previous(); !errors.Is(err, ErrNoPreviousPage) {
+		t.Errorf("Expected ErrNoPreviousPage before the first page, got %v", err)
+	}
+
+	steps := []struct {
+		name     string
+		move     func() (NamedAPIResourceList, error)
+		expected string
+	}{
+		{name: "first page", move: p.Next, expected: "first"},
+		{name: "second page", move: p.Next, expected: "second"},
+		{name: "back to first page", move: p.Previous, expected: "first"},
+	}
+	for _, step := range steps {
+		page, err := step.move()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if page.Results[0].Name != step.expected {
+			t.Errorf("%s: expected %s, got %s", step.name, step.expected, page.Results[0].Name)
+		}
+	}
+
+	if _, err := p.Next(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := p.Next(); !errors.Is(err, ErrNoNextPage) {
+		t.Errorf("Expected ErrNoNextPage after the last page, got %v", err)
+	}
+}
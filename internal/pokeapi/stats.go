@@ -0,0 +1,111 @@
+package pokeapi
+
+// Distribution of base stats across every Pokemon, used to rank one Pokemon
+// against the rest. Building it means fetching every Pokemon once, so callers
+// should keep the result (see CachedClient, and the copy saved on disk).
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+const aggregateWorkers = 8
+
+// Key used for the base stat total in StatAggregate.Stats
+const StatTotal = "total"
+
+type StatAggregate struct {
+	Stats map[string][]int `json:"stats"`
+	// Pokemon that couldn't be fetched and are left out of the rankings
+	Skipped int `json:"skipped,omitempty"`
+}
+
+func (p Pokemon) BaseStatTotal() int {
+	total := 0
+	for _, stat := range p.Stats {
+		total += stat.BaseStat
+	}
+	return total
+}
+
+// Percentage of Pokemon with a strictly lower value for the stat
+func (a StatAggregate) Percentile(stat string, value int) (float64, bool) {
+	values := a.Stats[stat]
+	if len(values) == 0 {
+		return 0, false
+	}
+	below := sort.SearchInts(values, value)
+	return float64(below) * 100 / float64(len(values)), true
+}
+
+func newStatAggregate(pokemon []Pokemon) StatAggregate {
+	aggregate := StatAggregate{Stats: make(map[string][]int)}
+	for _, p := range pokemon {
+		for _, stat := range p.Stats {
+			aggregate.Stats[stat.Stat.Name] = append(aggregate.Stats[stat.Stat.Name], stat.BaseStat)
+		}
+		aggregate.Stats[StatTotal] = append(aggregate.Stats[StatTotal], p.BaseStatTotal())
+	}
+	for _, values := range aggregate.Stats {
+		sort.Ints(values)
+	}
+	return aggregate
+}
+
+// A Pokemon that fails to load is skipped rather than failing the whole
+// ranking; only cancelling ctx (or every request failing) returns an error
+func (c *Client) GetStatAggregate(ctx context.Context) (StatAggregate, error) {
+	names, err := c.GetResourceNames(ctx, KindPokemon)
+	if err != nil {
+		return StatAggregate{}, err
+	}
+
+	jobs := make(chan string)
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		pokemon = make([]Pokemon, 0, len(names))
+		skipped int
+		lastErr error
+	)
+	for range aggregateWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range jobs {
+				p, err := c.getPokemon(ctx, name)
+				mu.Lock()
+				if err != nil {
+					skipped++
+					lastErr = err
+				} else {
+					pokemon = append(pokemon, p)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, name := range names {
+		select {
+		case jobs <- name:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return StatAggregate{}, err
+	}
+	if len(pokemon) == 0 && lastErr != nil {
+		return StatAggregate{}, fmt.Errorf("Couldn't fetch any Pokemon: %w", lastErr)
+	}
+	aggregate := newStatAggregate(pokemon)
+	aggregate.Skipped = skipped
+	return aggregate, nil
+}
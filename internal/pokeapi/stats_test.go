@@ -0,0 +1,111 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestStatAggregatePercentile(t *testing.T) {
+	withStats := func(hp, attack int) Pokemon {
+		return Pokemon{Stats: []PokemonStat{
+			{Stat: NamedAPIResource{Name: "hp"}, BaseStat: hp},
+			{Stat: NamedAPIResource{Name: "attack"}, BaseStat: attack},
+		}}
+	}
+	aggregate := newStatAggregate([]Pokemon{
+		withStats(30, 40),
+		withStats(50, 60),
+		withStats(70, 80),
+		withStats(90, 100),
+	})
+
+	tests := []struct {
+		name     string
+		stat     string
+		value    int
+		expected float64
+	}{
+		{name: "lowest", stat: "hp", value: 30, expected: 0},
+		{name: "middle", stat: "attack", value: 80, expected: 50},
+		{name: "above all", stat: "hp", value: 255, expected: 100},
+		{name: "total", stat: StatTotal, value: 130, expected: 50},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			percentile, ok := aggregate.Percentile(test.stat, test.value)
+			if !ok {
+				t.Fatalf("Expected stat %s to be ranked", test.stat)
+			}
+			if percentile != test.expected {
+				t.Errorf("Expected %.1f, got %.1f", test.expected, percentile)
+			}
+		})
+	}
+
+	if _, ok := aggregate.Percentile("luck", 10); ok {
+		t.Error("Expected unknown stat to be unranked")
+	}
+}
+
+func TestGetStatAggregateSkipsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon":
+			w.Write([]byte(`{"results": [{"name": "pikachu"}, {"name": "raichu"}, {"name": "missingno"}]}`))
+		case "/pokemon/pikachu/":
+			w.Write([]byte(`{"name": "pikachu", "stats": [{"base_stat": 90, "stat": {"name": "speed"}}]}`))
+		case "/pokemon/raichu/":
+			w.Write([]byte(`{"name": "raichu", "stats": [{"base_stat": 110, "stat": {"name": "speed"}}]}`))
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	aggregate, err := NewClient(time.Second, WithBaseURL(server.URL)).GetStatAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(aggregate.Stats["speed"]) != 2 || aggregate.Skipped != 1 {
+		t.Errorf("Expected 2 ranked and 1 skipped, got %v and %d skipped", aggregate.Stats["speed"], aggregate.Skipped)
+	}
+}
+
+func TestGetStatAggregateStopsWhenCancelled(t *testing.T) {
+	requests := make(chan struct{}, 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/pokemon" {
+			w.Write([]byte(`{"results": [{"name": "a"}, {"name": "b"}, {"name": "c"}]}`))
+			return
+		}
+		requests <- struct{}{}
+		// Hang until the client gives up
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-requests
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() {
+		_, err := NewClient(time.Minute, WithBaseURL(server.URL)).GetStatAggregate(ctx)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected in-flight requests to stop when cancelled")
+	}
+}
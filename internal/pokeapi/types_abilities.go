@@ -0,0 +1,18 @@
+package pokeapi
+
+type Ability struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+	Pokemon       []AbilityPokemon `json:"pokemon"`
+}
+
+type AbilityPokemon struct {
+	IsHidden bool             `json:"is_hidden"`
+	Slot     int              `json:"slot"`
+	Pokemon  NamedAPIResource `json:"pokemon"`
+}
+
+func (a Ability) EffectText() string {
+	return englishEffect(a.EffectEntries)
+}
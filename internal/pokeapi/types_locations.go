@@ -1,13 +1,5 @@
 package pokeapi
 
-type LocationArea struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
-}
+type LocationArea = NamedAPIResource
 
-type LocationAreaResponse struct {
-	Count    int            `json:"count"`
-	Next     *string        `json:"next"`
-	Previous *string        `json:"previous"`
-	Results  []LocationArea `json:"results"`
-}
+type LocationAreaResponse = NamedAPIResourceList
@@ -0,0 +1,43 @@
+package pokeapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Move struct {
+	ID            int              `json:"id"`
+	Name          string           `json:"name"`
+	Accuracy      *int             `json:"accuracy"`
+	Power         *int             `json:"power"`
+	PP            *int             `json:"pp"`
+	Priority      int              `json:"priority"`
+	EffectChance  *int             `json:"effect_chance"`
+	Type          NamedAPIResource `json:"type"`
+	DamageClass   NamedAPIResource `json:"damage_class"`
+	EffectEntries []VerboseEffect  `json:"effect_entries"`
+}
+
+type VerboseEffect struct {
+	Effect      string           `json:"effect"`
+	ShortEffect string           `json:"short_effect"`
+	Language    NamedAPIResource `json:"language"`
+}
+
+// English short effect with the move's effect chance filled in
+func (m Move) EffectText() string {
+	text := englishEffect(m.EffectEntries)
+	if m.EffectChance != nil {
+		text = strings.ReplaceAll(text, "$effect_chance", fmt.Sprint(*m.EffectChance))
+	}
+	return text
+}
+
+func englishEffect(entries []VerboseEffect) string {
+	for _, entry := range entries {
+		if entry.Language.Name == "en" {
+			return entry.ShortEffect
+		}
+	}
+	return ""
+}
@@ -0,0 +1,19 @@
+package pokeapi
+
+import "testing"
+
+func TestMoveEffectText(t *testing.T) {
+	chance := 10
+	move := Move{
+		EffectChance: &chance,
+		EffectEntries: []VerboseEffect{
+			{ShortEffect: "Hat eine $effect_chance% Chance.", Language: NamedAPIResource{Name: "de"}},
+			{ShortEffect: "Has a $effect_chance% chance to paralyze the target.", Language: NamedAPIResource{Name: "en"}},
+		},
+	}
+
+	expected := "Has a 10% chance to paralyze the target."
+	if got := move.EffectText(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
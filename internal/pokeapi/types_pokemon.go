@@ -2,35 +2,102 @@ package pokeapi
 
 type Pokemon struct {
 	Caught         int
-	ID             int    `json:"id"`
-	Name           string `json:"name"`
-	BaseExperience int    `json:"base_experience"`
-	Height         int    `json:"height"`
-	Weight         int    `json:"weight"`
-	Stats          []struct {
-		BaseStat int `json:"base_stat"`
-		Effort   int `json:"effort"`
-		Stat     struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"stat"`
-	} `json:"stats"`
-	Types []struct {
-		Type struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
+	ID             int                `json:"id"`
+	Name           string             `json:"name"`
+	BaseExperience int                `json:"base_experience"`
+	Height         int                `json:"height"`
+	Weight         int                `json:"weight"`
+	Stats          []PokemonStat      `json:"stats"`
+	Types          []PokemonType      `json:"types"`
+	Abilities      []PokemonAbility   `json:"abilities"`
+	HeldItems      []PokemonHeldItem  `json:"held_items"`
+	Moves          []PokemonMove      `json:"moves"`
+	Forms          []NamedAPIResource `json:"forms"`
+	Species        NamedAPIResource   `json:"species"`
+	Sprites        PokemonSprites     `json:"sprites"`
+}
+
+type PokemonStat struct {
+	BaseStat int              `json:"base_stat"`
+	Effort   int              `json:"effort"`
+	Stat     NamedAPIResource `json:"stat"`
+}
+
+type PokemonType struct {
+	Slot int              `json:"slot"`
+	Type NamedAPIResource `json:"type"`
+}
+
+type PokemonAbility struct {
+	IsHidden bool             `json:"is_hidden"`
+	Slot     int              `json:"slot"`
+	Ability  NamedAPIResource `json:"ability"`
+}
+
+type PokemonHeldItem struct {
+	Item           NamedAPIResource `json:"item"`
+	VersionDetails []struct {
+		Rarity  int              `json:"rarity"`
+		Version NamedAPIResource `json:"version"`
+	} `json:"version_details"`
+}
+
+type PokemonMove struct {
+	Move                NamedAPIResource     `json:"move"`
+	VersionGroupDetails []PokemonMoveVersion `json:"version_group_details"`
+}
+
+type PokemonMoveVersion struct {
+	LevelLearnedAt  int              `json:"level_learned_at"`
+	MoveLearnMethod NamedAPIResource `json:"move_learn_method"`
+	VersionGroup    NamedAPIResource `json:"version_group"`
+}
+
+type PokemonSprites struct {
+	FrontDefault *string `json:"front_default"`
+	FrontShiny   *string `json:"front_shiny"`
+	BackDefault  *string `json:"back_default"`
+	BackShiny    *string `json:"back_shiny"`
+	Other        struct {
+		OfficialArtwork struct {
+			FrontDefault *string `json:"front_default"`
+		} `json:"official-artwork"`
+	} `json:"other"`
+}
+
+func (p Pokemon) TypeNames() []string {
+	names := make([]string, 0, len(p.Types))
+	for _, t := range p.Types {
+		names = append(names, t.Type.Name)
+	}
+	return names
+}
+
+func (p Pokemon) BaseStat(name string) (int, bool) {
+	for _, stat := range p.Stats {
+		if stat.Stat.Name == name {
+			return stat.BaseStat, true
 		}
-	} `json:"types"`
+	}
+	return 0, false
+}
+
+// PokeAPI reports height in decimeters and weight in hectograms
+func (p Pokemon) HeightMeters() float64 {
+	return float64(p.Height) / 10
+}
+
+func (p Pokemon) WeightKilograms() float64 {
+	return float64(p.Weight) / 10
 }
 
 type PokemonInLocationResponse struct {
-	ID                int    `json:"id"`
-	Name              string `json:"name"`
-	GameIndex         int    `json:"game_index"`
-	PokemonEncounters []struct {
-		Pokemon struct {
-			Name string `json:"name"`
-			URL  string `json:"url"`
-		} `json:"pokemon"`
-	} `json:"pokemon_encounters"`
+	ID                int                `json:"id"`
+	Name              string             `json:"name"`
+	GameIndex         int                `json:"game_index"`
+	PokemonEncounters []PokemonEncounter `json:"pokemon_encounters"`
+}
+
+type PokemonEncounter struct {
+	Pokemon NamedAPIResource `json:"pokemon"`
 }
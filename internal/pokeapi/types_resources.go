@@ -0,0 +1,58 @@
+package pokeapi
+
+// Shapes shared by every PokeAPI list endpoint
+
+type NamedAPIResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type NamedAPIResourceList struct {
+	Count    int                `json:"count"`
+	Next     *string            `json:"next"`
+	Previous *string            `json:"previous"`
+	Results  []NamedAPIResource `json:"results"`
+}
+
+type ResourceKind string
+
+const (
+	KindPokemon        ResourceKind = "pokemon"
+	KindPokemonSpecies ResourceKind = "pokemon-species"
+	KindItem           ResourceKind = "item"
+	KindMove           ResourceKind = "move"
+	KindAbility        ResourceKind = "ability"
+	KindType           ResourceKind = "type"
+	KindNature         ResourceKind = "nature"
+	KindBerry          ResourceKind = "berry"
+	KindLocation       ResourceKind = "location"
+	KindLocationArea   ResourceKind = "location-area"
+	KindRegion         ResourceKind = "region"
+	KindGeneration     ResourceKind = "generation"
+	KindVersionGroup   ResourceKind = "version-group"
+)
+
+var ListableKinds = []ResourceKind{
+	KindPokemon,
+	KindPokemonSpecies,
+	KindItem,
+	KindMove,
+	KindAbility,
+	KindType,
+	KindNature,
+	KindBerry,
+	KindLocation,
+	KindLocationArea,
+	KindRegion,
+	KindGeneration,
+	KindVersionGroup,
+}
+
+func ParseResourceKind(name string) (ResourceKind, bool) {
+	for _, kind := range ListableKinds {
+		if string(kind) == name {
+			return kind, true
+		}
+	}
+	return "", false
+}
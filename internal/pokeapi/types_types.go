@@ -0,0 +1,38 @@
+package pokeapi
+
+type Type struct {
+	ID              int           `json:"id"`
+	Name            string        `json:"name"`
+	DamageRelations TypeRelations `json:"damage_relations"`
+}
+
+type TypeRelations struct {
+	NoDamageTo       []NamedAPIResource `json:"no_damage_to"`
+	HalfDamageTo     []NamedAPIResource `json:"half_damage_to"`
+	DoubleDamageTo   []NamedAPIResource `json:"double_damage_to"`
+	NoDamageFrom     []NamedAPIResource `json:"no_damage_from"`
+	HalfDamageFrom   []NamedAPIResource `json:"half_damage_from"`
+	DoubleDamageFrom []NamedAPIResource `json:"double_damage_from"`
+}
+
+// Damage multiplier of a move of this type against a single defending type
+func (t Type) Multiplier(defending string) float64 {
+	contains := func(resources []NamedAPIResource) bool {
+		for _, r := range resources {
+			if r.Name == defending {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case contains(t.DamageRelations.NoDamageTo):
+		return 0
+	case contains(t.DamageRelations.HalfDamageTo):
+		return 0.5
+	case contains(t.DamageRelations.DoubleDamageTo):
+		return 2
+	}
+	return 1
+}
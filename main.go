@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"math/rand"
@@ -13,98 +15,122 @@ import (
 )
 
 type config struct {
-	pokeClient          pokeapi.PokeAPIClient
-	nextLocationURL     *string
-	previousLocationURL *string
-	caughtPokemon       map[string]pokeapi.Pokemon
-	caughtCount         map[string]int
+	pokeClient    pokeapi.PokeAPIClient
+	paginators    map[string]*pokeapi.Paginator
+	caughtPokemon map[string]pokeapi.Pokemon
+	catches       []catchRecord
+	nextCatchID   int
+	currentArea   string
+	seen          map[string]bool
+	party         []int
+	boxes         []pcBox
+	profile       string
+	profilesDir   string
+	savePath      string
+	catchLogPath  string
+	rankingsPath  string
+	history       undoStack
+	settings      settings
+	rng           *rand.Rand
+	out           *renderer
+	rl            *readline.Instance
 }
 
-type cliCommand struct {
-	name        string
-	description string
-	callback    func(*config, string) error
+// Each listing keeps its own cursors so paging one doesn't disturb another
+func (cfg *config) paginator(listing string, fetch pokeapi.PageFetcher) *pokeapi.Paginator {
+	if cfg.paginators == nil {
+		cfg.paginators = make(map[string]*pokeapi.Paginator)
+	}
+	p, ok := cfg.paginators[listing]
+	if !ok {
+		p = pokeapi.NewPaginator(fetch)
+		cfg.paginators[listing] = p
+	}
+	return p
 }
 
-var commands = map[string]cliCommand{
-	"exit": {
-		name:        "exit",
-		description: "Exit the Pokedex",
-		callback:    commandExit,
-	},
-	"help": {
-		name:        "help",
-		description: "Displays a help message",
-		callback:    commandHelp,
-	},
-	"map": {
-		name:        "map",
-		description: "Displays the names of 20 location areas in the Pokemon world",
-		callback:    commandMap,
-	},
-	"mapb": {
-		name:        "mapb",
-		description: "Displays the name of the previous 20 location areas in the Pokemon world",
-		callback:    commandMapb,
-	},
-	"explore": {
-		name:        "explore",
-		description: "See a list of all the Pokemon located in a specific location area",
-		callback:    commandExplore,
-	},
-	"catch": {
-		name:        "catch",
-		description: "Catch a Pokemon and add it to your Pokedex",
-		callback:    commandCatch,
-	},
-	"inspect": {
-		name:        "inspect",
-		description: "View detailed information about a specific Pokemon",
-		callback:    commandInspect,
-	},
-	"pokedex": {
-		name:        "pokedex",
-		description: "View all the Pokemon you have caught so far",
-		callback:    commandPokedex,
-	},
-	"clear": {
-		name:        "clear",
-		description: "Clear your Pokedex",
-		callback:    commandClear,
-	},
+// Draws from the seeded generator when --seed was given, the global one otherwise
+func (cfg *config) intn(n int) int {
+	if cfg.rng == nil {
+		return rand.Intn(n)
+	}
+	return cfg.rng.Intn(n)
 }
 
 func main() {
+	opts, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+	if err != nil {
+		// The flag set has already reported it along with the usage
+		os.Exit(2)
+	}
+	if opts.showVersion {
+		fmt.Printf("pokedexcli %s\n", versionString())
+		return
+	}
+	if err := validateProfileName(opts.profile); err != nil {
+		log.Fatal(err)
+	}
+
+	settings, err := loadSettings(opts.configPath)
+	if err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+	for _, override := range opts.overrides {
+		if err := settings.set(override.key, override.value, sourceFlag); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	profilesDir := defaultProfilesDir()
+	legacySave := os.ExpandEnv("$HOME/.pokedexcli/save.json")
+	legacyHistory := os.ExpandEnv("$HOME/.pokedexcli_history")
+	if err := migrateLegacyProfile(profilesDir, legacySave, legacyHistory); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+
+	cfg := &config{
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+		profile:       opts.profile,
+		profilesDir:   profilesDir,
+		rankingsPath:  defaultRankingsPath(),
+		settings:      settings,
+	}
+	if opts.seedSet {
+		cfg.rng = rand.New(rand.NewSource(opts.seed))
+	}
+
 	rl, err := readline.NewEx(&readline.Config{
-		Prompt:      "Pokedex > ",
-		HistoryFile: os.ExpandEnv("$HOME/.pokedexcli_history"),
+		Prompt:      settings.prompt(opts.profile),
+		HistoryFile: cfg.historyPath(),
 	})
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer rl.Close()
+	cfg.rl = rl
 
-	useCache := os.Getenv("USE_CACHE") != "FALSE"
-	httpClient := pokeapi.NewClient(5 * time.Second)
-	var client pokeapi.PokeAPIClient
-
-	if useCache {
+	httpClient := pokeapi.NewClient(settings.duration("timeout"), pokeapi.WithBaseURL(settings.get("api_url")))
+	if settings.bool("cache") {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
-		cleanupInterval := 10 * time.Minute
-		cache := pokeapi.NewCache(ctx, cleanupInterval)
+		cache := pokeapi.NewCache(ctx, settings.duration("cache_cleanup"))
 
-		client = pokeapi.NewCachedClient(httpClient, cache, 5*time.Minute)
+		cfg.pokeClient = pokeapi.NewCachedClient(httpClient, cache, settings.duration("cache_ttl"))
 		fmt.Println("✅ Cache enabled")
 	} else {
-		client = httpClient
+		cfg.pokeClient = httpClient
 		fmt.Println("⚠️ Cache disabled")
 	}
 
-	cfg := &config{
-		pokeClient:    client,
-		caughtPokemon: make(map[string]pokeapi.Pokemon),
-		caughtCount:   make(map[string]int),
+	if err := cfg.applySettings(); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+		cfg.out, _ = newRenderer("default", settings.useColor())
+	}
+	if err := cfg.useProfile(opts.profile); err != nil {
+		fmt.Printf("⚠️ Could not load your Pokedex: %v\n", err)
 	}
 
 	fmt.Printf("\nWelcome to the Pokedex!\n" +
@@ -121,60 +147,35 @@ func main() {
 		}
 		value, ok := commands[cleanedInput[0]]
 		if !ok {
-			fmt.Print("Unknown command\n\n")
+			fmt.Print(cfg.out.errorText("Unknown command") + didYouMean(suggestNames(cleanedInput[0], commandNames(), 3)) + "\n\n")
 		} else {
-			arg := ""
-			if len(cleanedInput) > 1 {
-				arg = cleanedInput[1]
+			args := cleanedInput[1:]
+			if value.preserveCase {
+				args = splitArgs(line)[1:]
 			}
-			if err := value.callback(cfg, arg); err != nil {
-				fmt.Printf("Cannot execute command '%s': %v\n", value.name, err)
+			if err := runCommand(cfg, value, args...); err != nil {
+				fmt.Println(cfg.out.errorText(fmt.Sprintf("Cannot execute command '%s': %v", value.name, err)))
 			}
 		}
 	}
 }
 
-func commandExit(cfg *config, args string) error {
+func commandExit(cfg *config, args ...string) error {
 	fmt.Print("Closing the Pokedex... Goodbye!\n")
 	os.Exit(0)
 	return nil
 }
 
-func commandHelp(cfg *config, args string) error {
-	fmt.Print(
-		"Usage:\n\n" +
-			"	Pokedex > map\n" +
-			"		Displays a list of 20 location areas in the Pokemon world\n\n" +
-			"	Pokedex > mapb\n" +
-			"		Displays a list of the previous 20 location areas in the Pokemon world\n\n" +
-			"	Pokedex > explore <location-area-name>\n" +
-			"		See a list of all the Pokemon located in a specific location area\n\n" +
-			"	Pokedex > catch <pokemon-name>\n" +
-			"		Catch a Pokemon and add it to your Pokedex\n\n" +
-			"	Pokedex > inspect <pokemon-name>\n" +
-			"		View detailed information about a specific Pokemon\n\n" +
-			"	Pokedex > pokedex\n" +
-			"		View all the Pokemon you have caught so far\n\n" +
-			"	Pokedex > help\n" +
-			"		Displays a help message\n\n" +
-			"	Pokedex > clear\n" +
-			"		Clear your Pokedex\n\n" +
-			"	Pokedex > exit\n" +
-			"		Exit the Pokedex\n\n" +
-			"	Pokedex > press the up or down arrow\n" +
-			"		Browse through previously typed commands\n\n")
-	return nil
-}
-
-func commandMap(cfg *config, args string) error {
-	locationsResp, err := cfg.pokeClient.GetLocationAreas(cfg.nextLocationURL)
+func commandMap(cfg *config, args ...string) error {
+	locationsResp, err := cfg.paginator("location-area", cfg.pokeClient.GetLocationAreas).Next()
+	if errors.Is(err, pokeapi.ErrNoNextPage) {
+		fmt.Println("You're on the last page")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("Error fetching location areas: %w", err)
 	}
 
-	cfg.nextLocationURL = locationsResp.Next
-	cfg.previousLocationURL = locationsResp.Previous
-
 	for _, location := range locationsResp.Results {
 		fmt.Println(location.Name)
 	}
@@ -182,18 +183,15 @@ func commandMap(cfg *config, args string) error {
 	return nil
 }
 
-func commandMapb(cfg *config, args string) error {
-	if cfg.previousLocationURL == nil {
+func commandMapb(cfg *config, args ...string) error {
+	locationsResp, err := cfg.paginator("location-area", cfg.pokeClient.GetLocationAreas).Previous()
+	if errors.Is(err, pokeapi.ErrNoPreviousPage) {
 		fmt.Println("You're on the first page")
 		return nil
 	}
-
-	locationsResp, err := cfg.pokeClient.GetLocationAreas(cfg.previousLocationURL)
 	if err != nil {
 		return fmt.Errorf("Error fetching location areas: %w", err)
 	}
-	cfg.nextLocationURL = locationsResp.Next
-	cfg.previousLocationURL = locationsResp.Previous
 
 	for _, location := range locationsResp.Results {
 		fmt.Println(location.Name)
@@ -203,12 +201,14 @@ func commandMapb(cfg *config, args string) error {
 
 }
 
-func commandExplore(cfg *config, areaName string) error {
+func commandExplore(cfg *config, args ...string) error {
+	areaName := firstArg(args)
 	if areaName == "" {
 		return fmt.Errorf("Please provide a location to explore")
 	}
 
 	fmt.Printf("Exploring %s...\n\n", areaName)
+	cfg.currentArea = areaName
 
 	pokemonResp, err := cfg.pokeClient.GetPokemonInLocationArea(&areaName)
 	if err != nil {
@@ -223,93 +223,94 @@ func commandExplore(cfg *config, areaName string) error {
 	fmt.Printf("\nPokemon found:\n")
 	for _, encounter := range pokemonResp.PokemonEncounters {
 		fmt.Printf("- %s\n", encounter.Pokemon.Name)
+		cfg.markSeen(cfg.speciesOf(encounter.Pokemon.Name))
 	}
-	return nil
+	return cfg.save()
 }
 
-func commandCatch(cfg *config, pokemonName string) error {
+func commandCatch(cfg *config, args ...string) error {
+	pokemonName := firstArg(args)
 	if pokemonName == "" {
 		fmt.Printf("Please provide the name of the Pokemon to catch\n\n")
 		return nil
 	}
 
-	userBaseExperience := rand.Intn(201) + 50
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	userBaseExperience := cfg.intn(201) + 50
 
 	pokemon, err := cfg.pokeClient.GetPokemonInfo(pokemonName)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no Pokemon called '%s'.%s\n\n", pokemonName, didYouMean(cfg.pokemonSuggestions(pokemonName)))
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("Error fetching Pokemon '%s'\n"+
 			"%w", pokemonName, err)
 	}
 
-	if userBaseExperience > pokemon.BaseExperience {
-		if _, exists := cfg.caughtPokemon[pokemonName]; !exists {
-			cfg.caughtPokemon[pokemonName] = pokemon
-		}
-		cfg.caughtCount[pokemonName]++
-		if cfg.caughtCount[pokemonName] == 1 {
-			fmt.Printf("%s was caught!\n\n", pokemonName)
-		} else {
-			fmt.Printf("%s was caught again! Total: %d\n\n", pokemonName, cfg.caughtCount[pokemonName])
-		}
-
-	} else {
-		fmt.Printf("%s escaped!\n\n", pokemonName)
-	}
-
-	return nil
-}
+	// Dex numbers and names both resolve to the canonical name for storage
+	pokemonName = pokemon.Name
+	cfg.markSeen(pokemon.Name)
+	cfg.markSeen(pokemon.Species.Name)
+	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
 
-func commandInspect(cfg *config, pokemonName string) error {
-	if pokemonName == "" {
-		fmt.Printf("Please provide the name of the Pokemon to inspect\n\n")
-		return nil
+	caught := userBaseExperience > pokemon.BaseExperience
+	attempt := catchAttempt{
+		Time:           time.Now(),
+		Pokemon:        pokemonName,
+		Roll:           userBaseExperience,
+		BaseExperience: pokemon.BaseExperience,
+		Caught:         caught,
+		Area:           cfg.currentArea,
 	}
-	if cfg.caughtPokemon[pokemonName].Name == "" {
-		fmt.Printf("You haven't caught '%s' yet\n\n", pokemonName)
-		return nil
+	if err := cfg.logCatchAttempt(attempt); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
 	}
 
-	fmt.Printf("Inspecting %s...\n\n", pokemonName)
-
-	pokemon, err := cfg.pokeClient.GetPokemonInfo(pokemonName)
-	if err != nil {
-		return fmt.Errorf("Error fetching Pokemon '%s': %w", pokemonName, err)
-	}
+	if caught {
+		record := cfg.recordCatch(pokemon)
+		if err := cfg.save(); err != nil {
+			return err
+		}
+		total := cfg.timesCaught(pokemonName)
+		if total == 1 {
+			fmt.Printf("%s (#%d, Lv %d, %s)\n\n", cfg.out.success(pokemonName+" was caught!"), record.ID, record.Level, record.Nature)
+		} else {
+			fmt.Printf("%s (#%d, Lv %d, %s) Total: %d\n\n", cfg.out.success(pokemonName+" was caught again!"), record.ID, record.Level, record.Nature, total)
+		}
 
-	fmt.Printf("Name: %s\n", pokemon.Name)
-	fmt.Printf("Times caught: %d\n", cfg.caughtCount[pokemonName])
-	fmt.Printf("ID: %d\n", pokemon.ID)
-	fmt.Printf("Base Experience: %d\n", pokemon.BaseExperience)
-	fmt.Printf("Height: %d\n", pokemon.Height)
-	fmt.Printf("Weight: %d\n", pokemon.Weight)
-	fmt.Printf("Stats:\n")
-	for _, stat := range pokemon.Stats {
-		fmt.Printf("- %s: %d\n", stat.Stat.Name, stat.BaseStat)
-	}
-	fmt.Printf("Types:\n")
-	for _, typeInfo := range pokemon.Types {
-		fmt.Printf("- %s\n", typeInfo.Type.Name)
+	} else {
+		fmt.Printf("%s\n\n", cfg.out.failure(pokemonName+" escaped!"))
+		if err := cfg.save(); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-func commandPokedex(cfg *config, args string) error {
-	if len(cfg.caughtPokemon) == 0 {
-		fmt.Printf("You haven't caught any Pokemon yet\n\n")
-		return nil
+// Looks up a caught Pokemon by name or national dex number
+func (cfg *config) findCaught(identifier string) (pokeapi.Pokemon, bool) {
+	identifier = pokeapi.NormalizeIdentifier(identifier)
+	if pokemon, ok := cfg.caughtPokemon[identifier]; ok {
+		return pokemon, true
 	}
-
-	fmt.Printf("Your Pokedex:\n\n")
-	for _, pokemon := range cfg.caughtPokemon {
-		fmt.Printf("- %s\n\n", pokemon.Name)
+	if id, isID := pokeapi.ParseID(identifier); isID {
+		for _, pokemon := range cfg.caughtPokemon {
+			if pokemon.ID == id {
+				return pokemon, true
+			}
+		}
 	}
-	return nil
+	return pokeapi.Pokemon{}, false
 }
 
-func commandClear(cfg *config, args string) error {
+// Seen Pokemon and catch IDs survive, so a cleared Pokedex never reuses an ID
+func commandClear(cfg *config, args ...string) error {
 	cfg.pokeClient.Clear()
-	fmt.Printf("Your Pokedex has been cleared\n\n")
+	cfg.restore(saveData{NextCatchID: cfg.nextCatchID, Seen: cfg.seen})
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Your Pokedex has been cleared (use 'undo' to bring it back)\n\n")
 	return nil
 }
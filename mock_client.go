@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
 )
 
@@ -10,6 +12,14 @@ type mockClient struct {
 	getLocationAreasFunc         func(pageURL *string) (pokeapi.LocationAreaResponse, error)
 	getPokemonInfoFunc           func(pokemonName string) (pokeapi.Pokemon, error)
 	getPokemonInLocationAreaFunc func(areaURL *string) (pokeapi.PokemonInLocationResponse, error)
+	listResourcesFunc            func(kind pokeapi.ResourceKind, page *string) (pokeapi.NamedAPIResourceList, error)
+	getResourceNamesFunc         func(kind pokeapi.ResourceKind) ([]string, error)
+	getGenerationFunc            func(name string) (pokeapi.Generation, error)
+	getTypeFunc                  func(name string) (pokeapi.Type, error)
+	getSpriteFunc                func(spriteURL string) ([]byte, error)
+	getStatAggregateFunc         func() (pokeapi.StatAggregate, error)
+	getMoveFunc                  func(name string) (pokeapi.Move, error)
+	getAbilityFunc               func(name string) (pokeapi.Ability, error)
 }
 
 func (m *mockClient) GetLocationAreas(pageURL *string) (pokeapi.LocationAreaResponse, error) {
@@ -33,4 +43,60 @@ func (m *mockClient) GetPokemonInLocationArea(areaURL *string) (pokeapi.PokemonI
 	return m.getPokemonInLocationAreaFunc(areaURL)
 }
 
+func (m *mockClient) ListResources(ctx context.Context, kind pokeapi.ResourceKind, page *string) (pokeapi.NamedAPIResourceList, error) {
+	if m.listResourcesFunc == nil {
+		return pokeapi.NamedAPIResourceList{}, nil
+	}
+	return m.listResourcesFunc(kind, page)
+}
+
+func (m *mockClient) GetResourceNames(ctx context.Context, kind pokeapi.ResourceKind) ([]string, error) {
+	if m.getResourceNamesFunc == nil {
+		return nil, nil
+	}
+	return m.getResourceNamesFunc(kind)
+}
+
+func (m *mockClient) GetGeneration(ctx context.Context, name string) (pokeapi.Generation, error) {
+	if m.getGenerationFunc == nil {
+		return pokeapi.Generation{}, nil
+	}
+	return m.getGenerationFunc(name)
+}
+
+func (m *mockClient) GetType(ctx context.Context, name string) (pokeapi.Type, error) {
+	if m.getTypeFunc == nil {
+		return pokeapi.Type{Name: name}, nil
+	}
+	return m.getTypeFunc(name)
+}
+
+func (m *mockClient) GetSprite(ctx context.Context, spriteURL string) ([]byte, error) {
+	if m.getSpriteFunc == nil {
+		return nil, nil
+	}
+	return m.getSpriteFunc(spriteURL)
+}
+
+func (m *mockClient) GetStatAggregate(ctx context.Context) (pokeapi.StatAggregate, error) {
+	if m.getStatAggregateFunc == nil {
+		return pokeapi.StatAggregate{}, nil
+	}
+	return m.getStatAggregateFunc()
+}
+
+func (m *mockClient) GetMove(ctx context.Context, name string) (pokeapi.Move, error) {
+	if m.getMoveFunc == nil {
+		return pokeapi.Move{Name: name}, nil
+	}
+	return m.getMoveFunc(name)
+}
+
+func (m *mockClient) GetAbility(ctx context.Context, name string) (pokeapi.Ability, error) {
+	if m.getAbilityFunc == nil {
+		return pokeapi.Ability{Name: name}, nil
+	}
+	return m.getAbilityFunc(name)
+}
+
 func (m *mockClient) Clear() {}
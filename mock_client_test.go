@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
@@ -28,20 +29,16 @@ func TestCaughtCount(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			cfg := &config{
 				caughtPokemon: make(map[string]pokeapi.Pokemon),
-				caughtCount:   make(map[string]int),
 			}
 
 			pokemon := pokeapi.Pokemon{Name: "pikachu", BaseExperience: 50}
 
 			for i := 0; i < test.catchTimes; i++ {
-				if _, exists := cfg.caughtPokemon["pikachu"]; !exists {
-					cfg.caughtPokemon["pikachu"] = pokemon
-				}
-				cfg.caughtCount["pikachu"]++
+				cfg.recordCatch(pokemon)
 			}
 
-			if cfg.caughtCount["pikachu"] != test.expectedCount {
-				t.Errorf("Expected count %d, got %d", test.expectedCount, cfg.caughtCount["pikachu"])
+			if cfg.timesCaught("pikachu") != test.expectedCount {
+				t.Errorf("Expected count %d, got %d", test.expectedCount, cfg.timesCaught("pikachu"))
 			}
 
 			if len(cfg.caughtPokemon) != 1 {
@@ -105,11 +102,12 @@ func TestCommandMap(t *testing.T) {
 			}
 
 			if !test.expectedError {
-				if cfg.nextLocationURL != test.mockResponse.Next {
-					t.Error("Expected nextLocationURL to be updated")
+				locations := cfg.paginators["location-area"]
+				if locations.NextURL() != test.mockResponse.Next {
+					t.Error("Expected next location URL to be updated")
 				}
-				if cfg.previousLocationURL != test.mockResponse.Previous {
-					t.Errorf("Expected previousLocationURL to be updated")
+				if locations.PreviousURL() != test.mockResponse.Previous {
+					t.Errorf("Expected previous location URL to be updated")
 				}
 			}
 
@@ -137,11 +135,499 @@ func TestCommandMapPagination(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	if cfg.nextLocationURL == nil || *cfg.nextLocationURL != nextURL {
-		t.Errorf("Expected nextLocationURL %s, got %v", nextURL, cfg.nextLocationURL)
+	locations := cfg.paginators["location-area"]
+	if locations.NextURL() == nil || *locations.NextURL() != nextURL {
+		t.Errorf("Expected next location URL %s, got %v", nextURL, locations.NextURL())
+	}
+	if locations.PreviousURL() == nil || *locations.PreviousURL() != prevURL {
+		t.Errorf("Expected previous location URL %s, got %v", prevURL, locations.PreviousURL())
+	}
+
+}
+
+func TestPaginatorsAreIndependent(t *testing.T) {
+	nextURL := "https://pokeapi.co/api/v2/location-area?offset=20"
+
+	cfg := &config{
+		pokeClient: &mockClient{
+			getLocationAreasFunc: func(pageURL *string) (pokeapi.LocationAreaResponse, error) {
+				return pokeapi.LocationAreaResponse{Next: &nextURL}, nil
+			},
+		},
+	}
+
+	if err := commandMap(cfg, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	other := cfg.paginator("pokemon", func(pageURL *string) (pokeapi.NamedAPIResourceList, error) {
+		return pokeapi.NamedAPIResourceList{}, nil
+	})
+	if other.NextURL() != nil {
+		t.Errorf("Expected a fresh paginator for another listing, got next URL %v", *other.NextURL())
+	}
+	if cfg.paginators["location-area"].NextURL() == nil {
+		t.Error("Expected location cursor to be kept")
+	}
+}
+
+func TestCommandList(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedKind  pokeapi.ResourceKind
+		expectedCalls int
+		expectedError bool
+	}{
+		{
+			name:          "lists pokemon",
+			args:          []string{"pokemon"},
+			expectedKind:  pokeapi.KindPokemon,
+			expectedCalls: 1,
+		},
+		{
+			name:          "lists moves",
+			args:          []string{"move", "next"},
+			expectedKind:  pokeapi.KindMove,
+			expectedCalls: 1,
+		},
+		{
+			name:          "unknown resource",
+			args:          []string{"gym-leader"},
+			expectedError: true,
+		},
+		{
+			name:          "previous page before any page",
+			args:          []string{"item", "prev"},
+			expectedCalls: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			calls := 0
+			cfg := &config{
+				pokeClient: &mockClient{
+					listResourcesFunc: func(kind pokeapi.ResourceKind, page *string) (pokeapi.NamedAPIResourceList, error) {
+						calls++
+						if kind != test.expectedKind {
+							t.Errorf("Expected kind %s, got %s", test.expectedKind, kind)
+						}
+						return pokeapi.NamedAPIResourceList{}, nil
+					},
+				},
+			}
+
+			err := commandList(cfg, test.args...)
+			if test.expectedError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !test.expectedError && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if calls != test.expectedCalls {
+				t.Errorf("Expected %d calls, got %d", test.expectedCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCatchByDexNumber(t *testing.T) {
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				return pokeapi.Pokemon{ID: 25, Name: "pikachu", BaseExperience: 0}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+
+	for _, identifier := range []string{"25", "pikachu", "#025"} {
+		if err := commandCatch(cfg, identifier); err != nil {
+			t.Fatalf("Unexpected error catching %s: %v", identifier, err)
+		}
+	}
+
+	if len(cfg.caughtPokemon) != 1 {
+		t.Errorf("Expected 1 pokemon in pokedex, got %d", len(cfg.caughtPokemon))
+	}
+	if cfg.timesCaught("pikachu") != 3 {
+		t.Errorf("Expected count 3 for pikachu, got %d", cfg.timesCaught("pikachu"))
+	}
+	if _, ok := cfg.findCaught("25"); !ok {
+		t.Error("Expected to find pikachu by dex number")
+	}
+}
+
+func TestCatchRecords(t *testing.T) {
+	cfg := &config{
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+	pikachu := pokeapi.Pokemon{ID: 25, Name: "pikachu"}
+
+	first := cfg.recordCatch(pikachu)
+	second := cfg.recordCatch(pikachu)
+	if first.ID == second.ID {
+		t.Fatalf("Expected unique catch IDs, got %d twice", first.ID)
+	}
+
+	if err := commandRename(cfg, "2", "Sparky"); err != nil {
+		t.Fatalf("Unexpected error renaming: %v", err)
+	}
+	if err := commandRename(cfg, "1", "sparky"); err == nil {
+		t.Error("Expected duplicate nickname to be rejected")
+	}
+	if err := commandRename(cfg, "1", "Big", "Sparky"); err == nil {
+		t.Error("Expected a nickname with spaces to be rejected")
+	}
+	if record, ok := cfg.findCatch("SPARKY"); !ok || record.ID != second.ID {
+		t.Errorf("Expected to find #%d by nickname", second.ID)
+	}
+
+	if err := commandRelease(cfg, "sparky"); err != nil {
+		t.Fatalf("Unexpected error releasing: %v", err)
+	}
+	if cfg.timesCaught("pikachu") != 1 {
+		t.Errorf("Expected 1 pikachu left, got %d", cfg.timesCaught("pikachu"))
+	}
+
+	if err := commandRelease(cfg, "#1"); err != nil {
+		t.Fatalf("Unexpected error releasing: %v", err)
+	}
+	if _, ok := cfg.caughtPokemon["pikachu"]; ok {
+		t.Error("Expected pikachu to leave the Pokedex after its last release")
+	}
+}
+
+func TestSeenAndCaughtProgress(t *testing.T) {
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInLocationAreaFunc: func(areaURL *string) (pokeapi.PokemonInLocationResponse, error) {
+				return pokeapi.PokemonInLocationResponse{
+					PokemonEncounters: []pokeapi.PokemonEncounter{
+						{Pokemon: pokeapi.NamedAPIResource{Name: "pikachu"}},
+						{Pokemon: pokeapi.NamedAPIResource{Name: "caterpie"}},
+					},
+				}, nil
+			},
+			getResourceNamesFunc: func(kind pokeapi.ResourceKind) ([]string, error) {
+				return []string{"bulbasaur", "caterpie", "pikachu"}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+
+	if err := commandExplore(cfg, "viridian-forest-area"); err != nil {
+		t.Fatalf("Unexpected error exploring: %v", err)
+	}
+	record := cfg.recordCatch(pokeapi.Pokemon{Name: "pikachu", Species: pokeapi.NamedAPIResource{Name: "pikachu"}})
+	if record.Location != "viridian-forest-area" {
+		t.Errorf("Expected catch location viridian-forest-area, got %q", record.Location)
+	}
+
+	seen, caught := cfg.speciesProgress()
+	if !seen["caterpie"] || !seen["pikachu"] || seen["bulbasaur"] {
+		t.Errorf("Unexpected seen set: %v", seen)
+	}
+	if !caught["pikachu"] || caught["caterpie"] {
+		t.Errorf("Unexpected caught set: %v", caught)
+	}
+
+	species := []string{"bulbasaur", "caterpie", "pikachu"}
+	expected := "seen 2/3 (66.7%), caught 1/3 (33.3%)"
+	if actual := completion(species, seen, caught); actual != expected {
+		t.Errorf("Expected %q, got %q", expected, actual)
+	}
+
+	if err := commandPokedex(cfg, "--missing"); err != nil {
+		t.Errorf("Unexpected error listing missing species: %v", err)
+	}
+}
+
+func TestExploreSeesFormsAsSpecies(t *testing.T) {
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInLocationAreaFunc: func(areaURL *string) (pokeapi.PokemonInLocationResponse, error) {
+				return pokeapi.PokemonInLocationResponse{
+					PokemonEncounters: []pokeapi.PokemonEncounter{
+						{Pokemon: pokeapi.NamedAPIResource{Name: "basculin-red-striped"}},
+					},
+				}, nil
+			},
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				return pokeapi.Pokemon{Name: pokemonName, Species: pokeapi.NamedAPIResource{Name: "basculin"}}, nil
+			},
+			getResourceNamesFunc: func(kind pokeapi.ResourceKind) ([]string, error) {
+				return []string{"basculin", "pikachu"}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+
+	captureStdout(t, func() {
+		if err := commandExplore(cfg, "route-4-area"); err != nil {
+			t.Errorf("Unexpected error exploring: %v", err)
+		}
+	})
+	missing := captureStdout(t, func() {
+		if err := commandPokedex(cfg, "--missing"); err != nil {
+			t.Errorf("Unexpected error listing missing species: %v", err)
+		}
+	})
+	if !strings.Contains(missing, "Seen but not caught (1):\n- basculin\n") || !strings.Contains(missing, "Not seen yet (1):\n- pikachu\n") {
+		t.Errorf("Expected basculin to count as seen, got:\n%s", missing)
+	}
+}
+
+func TestSortAndFilterPokedex(t *testing.T) {
+	newPokemon := func(id int, name, typeName string, exp, attack int) pokeapi.Pokemon {
+		return pokeapi.Pokemon{
+			ID:             id,
+			Name:           name,
+			BaseExperience: exp,
+			Types:          []pokeapi.PokemonType{{Type: pokeapi.NamedAPIResource{Name: typeName}}},
+			Stats:          []pokeapi.PokemonStat{{Stat: pokeapi.NamedAPIResource{Name: "attack"}, BaseStat: attack}},
+		}
+	}
+
+	cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	charmander := newPokemon(4, "charmander", "fire", 62, 52)
+	pikachu := newPokemon(25, "pikachu", "electric", 112, 55)
+	arcanine := newPokemon(59, "arcanine", "fire", 194, 110)
+	cfg.recordCatch(pikachu)
+	cfg.recordCatch(arcanine)
+	cfg.recordCatch(charmander)
+	cfg.recordCatch(charmander)
+
+	entries := []pokeapi.Pokemon{pikachu, arcanine, charmander}
+	tests := []struct {
+		key      string
+		expected []string
+	}{
+		{key: "", expected: []string{"charmander", "pikachu", "arcanine"}},
+		{key: "name", expected: []string{"arcanine", "charmander", "pikachu"}},
+		{key: "caught", expected: []string{"charmander", "pikachu", "arcanine"}},
+		{key: "exp", expected: []string{"arcanine", "pikachu", "charmander"}},
+	}
+	for _, test := range tests {
+		t.Run("sort "+test.key, func(t *testing.T) {
+			sorted := append([]pokeapi.Pokemon(nil), entries...)
+			if err := sortPokedex(cfg, sorted, test.key); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for i, pokemon := range sorted {
+				if pokemon.Name != test.expected[i] {
+					t.Errorf("Expected %v at %d, got %s", test.expected[i], i, pokemon.Name)
+				}
+			}
+		})
+	}
+
+	if err := sortPokedex(cfg, entries, "weight"); err == nil {
+		t.Error("Expected unknown sort key to be rejected")
+	}
+
+	minStats, err := parseMinStats("attack=80")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if meetsMinStats(charmander, minStats) || !meetsMinStats(arcanine, minStats) {
+		t.Error("Expected only arcanine to reach attack 80")
+	}
+	if _, err := parseMinStats("luck=10"); err == nil {
+		t.Error("Expected unknown stat to be rejected")
+	}
+
+	if err := commandPokedex(cfg, "--type", "fire", "--sort", "exp"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestBestMatchup(t *testing.T) {
+	resource := func(name string) []pokeapi.NamedAPIResource {
+		return []pokeapi.NamedAPIResource{{Name: name}}
+	}
+	typeChart := map[string]pokeapi.Type{
+		"electric": {Name: "electric", DamageRelations: pokeapi.TypeRelations{
+			DoubleDamageTo: resource("water"),
+			NoDamageTo:     resource("ground"),
+		}},
+		"water": {Name: "water", DamageRelations: pokeapi.TypeRelations{
+			DoubleDamageTo: resource("ground"),
+		}},
+		"ground": {Name: "ground", DamageRelations: pokeapi.TypeRelations{
+			DoubleDamageTo: resource("electric"),
+		}},
+	}
+	cfg := &config{
+		pokeClient: &mockClient{
+			getTypeFunc: func(name string) (pokeapi.Type, error) {
+				return typeChart[name], nil
+			},
+		},
+	}
+	withTypes := func(name string, types ...string) pokeapi.Pokemon {
+		pokemon := pokeapi.Pokemon{Name: name}
+		for _, typeName := range types {
+			pokemon.Types = append(pokemon.Types, pokeapi.PokemonType{Type: pokeapi.NamedAPIResource{Name: typeName}})
+		}
+		return pokemon
+	}
+
+	tests := []struct {
+		name             string
+		attacker         pokeapi.Pokemon
+		defender         pokeapi.Pokemon
+		expectedType     string
+		expectedMultiple float64
+	}{
+		{
+			name:             "super effective",
+			attacker:         withTypes("pikachu", "electric"),
+			defender:         withTypes("squirtle", "water"),
+			expectedType:     "electric",
+			expectedMultiple: 2,
+		},
+		{
+			name:             "immune",
+			attacker:         withTypes("pikachu", "electric"),
+			defender:         withTypes("sandshrew", "ground"),
+			expectedType:     "electric",
+			expectedMultiple: 0,
+		},
+		{
+			name:             "best of two types",
+			attacker:         withTypes("quagsire", "water", "ground"),
+			defender:         withTypes("pikachu", "electric"),
+			expectedType:     "ground",
+			expectedMultiple: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attackType, multiplier, err := cfg.bestMatchup(test.attacker, test.defender)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if attackType != test.expectedType || multiplier != test.expectedMultiple {
+				t.Errorf("Expected %s %gx, got %s %gx", test.expectedType, test.expectedMultiple, attackType, multiplier)
+			}
+		})
+	}
+}
+
+func TestLevelUpLearnsets(t *testing.T) {
+	levelUp := pokeapi.NamedAPIResource{Name: "level-up"}
+	machine := pokeapi.NamedAPIResource{Name: "machine"}
+	pikachu := pokeapi.Pokemon{
+		Name: "pikachu",
+		Moves: []pokeapi.PokemonMove{
+			{
+				Move: pokeapi.NamedAPIResource{Name: "thunderbolt"},
+				VersionGroupDetails: []pokeapi.PokemonMoveVersion{
+					{LevelLearnedAt: 26, MoveLearnMethod: levelUp, VersionGroup: pokeapi.NamedAPIResource{Name: "red-blue"}},
+					{MoveLearnMethod: machine, VersionGroup: pokeapi.NamedAPIResource{Name: "scarlet-violet"}},
+				},
+			},
+			{
+				Move: pokeapi.NamedAPIResource{Name: "thunder-shock"},
+				VersionGroupDetails: []pokeapi.PokemonMoveVersion{
+					{LevelLearnedAt: 1, MoveLearnMethod: levelUp, VersionGroup: pokeapi.NamedAPIResource{Name: "red-blue"}},
+					{LevelLearnedAt: 1, MoveLearnMethod: levelUp, VersionGroup: pokeapi.NamedAPIResource{Name: "scarlet-violet"}},
+				},
+			},
+		},
+	}
+
+	learnsets := levelUpLearnsets(pikachu)
+	redBlue := learnsets["red-blue"]
+	if len(redBlue) != 2 || redBlue[0].move != "thunder-shock" || redBlue[1].move != "thunderbolt" {
+		t.Errorf("Expected red-blue learnset ordered by level, got %v", redBlue)
+	}
+	if len(learnsets["scarlet-violet"]) != 1 {
+		t.Errorf("Expected machine moves to be left out, got %v", learnsets["scarlet-violet"])
+	}
+
+	cfg := &config{
+		pokeClient: &mockClient{
+			getResourceNamesFunc: func(kind pokeapi.ResourceKind) ([]string, error) {
+				return []string{"red-blue", "gold-silver", "scarlet-violet"}, nil
+			},
+		},
+	}
+	latest, err := cfg.latestVersionGroup(learnsets)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if latest != "scarlet-violet" {
+		t.Errorf("Expected scarlet-violet, got %s", latest)
+	}
+
+	cfg.caughtPokemon = map[string]pokeapi.Pokemon{"pikachu": pikachu}
+	if err := commandLearnset(cfg, "pikachu", "gold-silver"); err == nil {
+		t.Error("Expected an error for a version group without level-up moves")
 	}
-	if cfg.previousLocationURL == nil || *cfg.previousLocationURL != prevURL {
-		t.Errorf("Expected previsousLocationURL %s, got %v", prevURL, cfg.previousLocationURL)
+	if err := commandLearnset(cfg, "pikachu", "red-blue", "--details"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := commandLearnset(cfg, "pikachu", "red-blue", "--detials"); err == nil {
+		t.Error("Expected an error for an unknown flag")
 	}
 
+	// Version groups the index doesn't know yet are reported, not guessed at
+	cfg.pokeClient = &mockClient{
+		getResourceNamesFunc: func(kind pokeapi.ResourceKind) ([]string, error) {
+			return []string{"gold-silver"}, nil
+		},
+	}
+	_, err = cfg.latestVersionGroup(learnsets)
+	if err == nil || !strings.Contains(err.Error(), "red-blue, scarlet-violet") {
+		t.Errorf("Expected an error listing the version groups, got %v", err)
+	}
+}
+
+func TestCommandAbility(t *testing.T) {
+	tests := []struct {
+		name          string
+		ability       string
+		mockError     error
+		expectedError bool
+	}{
+		{name: "known ability", ability: "static"},
+		{name: "misspelled ability", ability: "statc", mockError: pokeapi.ErrNotFound},
+		{name: "API error", ability: "static", mockError: fmt.Errorf("API unavailable"), expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requested := ""
+			cfg := &config{
+				pokeClient: &mockClient{
+					getAbilityFunc: func(name string) (pokeapi.Ability, error) {
+						requested = name
+						return pokeapi.Ability{
+							Name: "static",
+							Pokemon: []pokeapi.AbilityPokemon{
+								{Pokemon: pokeapi.NamedAPIResource{Name: "pikachu"}},
+								{Pokemon: pokeapi.NamedAPIResource{Name: "electrike"}, IsHidden: true},
+							},
+						}, test.mockError
+					},
+				},
+				caughtPokemon: map[string]pokeapi.Pokemon{"pikachu": {Name: "pikachu"}},
+			}
+
+			err := commandAbility(cfg, test.ability)
+			if test.expectedError && err == nil {
+				t.Errorf("Expected error but got nil")
+			}
+			if !test.expectedError && err != nil {
+				t.Errorf("Expected no error but got %v", err)
+			}
+			if requested != test.ability {
+				t.Errorf("Expected ability %s to be requested, got %s", test.ability, requested)
+			}
+		})
+	}
 }
@@ -0,0 +1,157 @@
+package main
+
+// The party is an ordered selection of up to six catch records. It is the
+// default roster for anything that needs a team (battles, trades, exports).
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxPartySize = 6
+
+func commandParty(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return printParty(cfg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		return printParty(cfg)
+	case "add":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the catch ID, nickname or species to add\n\n")
+			return nil
+		}
+		return partyAdd(cfg, args[1])
+	case "remove":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the catch ID, nickname or species to remove\n\n")
+			return nil
+		}
+		return partyRemove(cfg, args[1])
+	case "swap":
+		if len(args) < 3 {
+			fmt.Printf("Please provide the two party slots to swap (1-%d)\n\n", maxPartySize)
+			return nil
+		}
+		return partySwap(cfg, args[1], args[2])
+	case "export":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the format to export (showdown) and optionally a file\n\n")
+			return nil
+		}
+		return partyExport(cfg, args[1], firstArg(args[2:]))
+	case "import":
+		if len(args) < 3 {
+			fmt.Printf("Please provide the format (showdown) and the file to import\n\n")
+			return nil
+		}
+		return partyImport(cfg, args[1], args[2])
+	}
+	return fmt.Errorf("Unknown party command '%s', expected list, add, remove, swap, export or import", args[0])
+}
+
+// The party as catch records, in slot order
+func (cfg *config) partyRecords() []catchRecord {
+	records := make([]catchRecord, 0, len(cfg.party))
+	for _, id := range cfg.party {
+		if record, ok := cfg.findCatch(strconv.Itoa(id)); ok {
+			records = append(records, *record)
+		}
+	}
+	return records
+}
+
+func printParty(cfg *config) error {
+	records := cfg.partyRecords()
+	if len(records) == 0 {
+		fmt.Printf("Your party is empty. Add Pokemon with 'party add <catch-id|nickname|species>'\n\n")
+		return nil
+	}
+
+	fmt.Printf("Your party (%d/%d):\n\n", len(records), maxPartySize)
+	rows := [][]string{headings(cfg, []string{"SLOT", "ID", "NAME", "LV", "TYPES"})}
+	for i, record := range records {
+		rows = append(rows, []string{
+			fmt.Sprint(i + 1), fmt.Sprintf("#%d", record.ID), record.displayName(),
+			fmt.Sprint(record.Level), cfg.out.typeNames(cfg.caughtPokemon[record.Species].TypeNames()),
+		})
+	}
+	printTable(rows, 0, 3)
+	fmt.Println()
+	return nil
+}
+
+func partyAdd(cfg *config, ref string) error {
+	record, ok := cfg.resolveCatch(ref, func(r catchRecord) bool {
+		return !slices.Contains(cfg.party, r.ID)
+	})
+	if !ok {
+		return fmt.Errorf("You haven't caught '%s' (or all of them are already in your party)", ref)
+	}
+	if len(cfg.party) >= maxPartySize {
+		return fmt.Errorf("Your party is full (%d/%d), remove a Pokemon first", len(cfg.party), maxPartySize)
+	}
+
+	if box, inBox := cfg.boxOf(record.ID); inBox {
+		fmt.Printf("#%d %s was taken out of box '%s'\n", record.ID, record.displayName(), box.Name)
+		cfg.removeFromBoxes(record.ID)
+	}
+	cfg.party = append(cfg.party, record.ID)
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s joined your party in slot %d\n\n", record.ID, record.displayName(), len(cfg.party))
+	return nil
+}
+
+func partyRemove(cfg *config, ref string) error {
+	record, ok := cfg.resolveCatch(ref, func(r catchRecord) bool {
+		return slices.Contains(cfg.party, r.ID)
+	})
+	if !ok {
+		return fmt.Errorf("'%s' isn't in your party", ref)
+	}
+
+	cfg.removeFromParty(record.ID)
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("#%d %s left your party\n\n", record.ID, record.displayName())
+	return nil
+}
+
+func partySwap(cfg *config, first, second string) error {
+	i, err := cfg.partySlot(first)
+	if err != nil {
+		return err
+	}
+	j, err := cfg.partySlot(second)
+	if err != nil {
+		return err
+	}
+
+	cfg.party[i], cfg.party[j] = cfg.party[j], cfg.party[i]
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	return printParty(cfg)
+}
+
+// Converts a 1-based slot as typed by the user into an index into cfg.party
+func (cfg *config) partySlot(slot string) (int, error) {
+	n, err := strconv.Atoi(slot)
+	if err != nil || n < 1 || n > len(cfg.party) {
+		return 0, fmt.Errorf("Invalid party slot '%s', expected 1-%d", slot, len(cfg.party))
+	}
+	return n - 1, nil
+}
+
+func (cfg *config) removeFromParty(id int) {
+	cfg.party = slices.DeleteFunc(cfg.party, func(member int) bool {
+		return member == id
+	})
+}
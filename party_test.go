@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestParty(t *testing.T) {
+	cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	pikachu := pokeapi.Pokemon{ID: 25, Name: "pikachu"}
+	for range maxPartySize + 1 {
+		cfg.recordCatch(pikachu)
+	}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 1, Name: "bulbasaur"})
+
+	if err := commandParty(cfg, "add", "snorlax"); err == nil {
+		t.Error("Expected adding an uncaught Pokemon to fail")
+	}
+
+	// Adding by species picks the next pikachu not already in the party
+	for range maxPartySize {
+		if err := commandParty(cfg, "add", "pikachu"); err != nil {
+			t.Fatalf("Unexpected error adding: %v", err)
+		}
+	}
+	if len(cfg.party) != maxPartySize {
+		t.Fatalf("Expected a full party, got %v", cfg.party)
+	}
+	if cfg.party[0] == cfg.party[1] {
+		t.Errorf("Expected distinct pikachus, got %v", cfg.party)
+	}
+	if err := commandParty(cfg, "add", "bulbasaur"); err == nil {
+		t.Error("Expected adding to a full party to fail")
+	}
+
+	first, second := cfg.party[0], cfg.party[1]
+	if err := commandParty(cfg, "swap", "1", "2"); err != nil {
+		t.Fatalf("Unexpected error swapping: %v", err)
+	}
+	if cfg.party[0] != second || cfg.party[1] != first {
+		t.Errorf("Expected slots 1 and 2 swapped, got %v", cfg.party)
+	}
+	if err := commandParty(cfg, "swap", "1", "7"); err == nil {
+		t.Error("Expected an invalid slot to be rejected")
+	}
+
+	if err := commandParty(cfg, "remove", "#1"); err != nil {
+		t.Fatalf("Unexpected error removing: %v", err)
+	}
+	if len(cfg.party) != maxPartySize-1 {
+		t.Errorf("Expected %d party members, got %v", maxPartySize-1, cfg.party)
+	}
+
+	// Releasing a party member also takes it out of the party
+	cfg.releaseCatch(cfg.party[0])
+	if len(cfg.party) != maxPartySize-2 {
+		t.Errorf("Expected released Pokemon to leave the party, got %v", cfg.party)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+// Every successful catch becomes its own record, so two pikachus can differ
+// in level, IVs, nature and nickname. caughtPokemon still holds one species
+// snapshot per Pokemon, shared by all of its records.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const (
+	maxCatchLevel = 50
+	maxIV         = 31
+)
+
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+var natures = []string{
+	"hardy", "lonely", "brave", "adamant", "naughty",
+	"bold", "docile", "relaxed", "impish", "lax",
+	"timid", "hasty", "serious", "jolly", "naive",
+	"modest", "mild", "quiet", "bashful", "rash",
+	"calm", "gentle", "sassy", "careful", "quirky",
+}
+
+type catchRecord struct {
+	ID       int            `json:"id"`
+	Species  string         `json:"species"`
+	Nickname string         `json:"nickname,omitempty"`
+	CaughtAt time.Time      `json:"caught_at"`
+	Location string         `json:"location,omitempty"`
+	Level    int            `json:"level"`
+	IVs      map[string]int `json:"ivs"`
+	Nature   string         `json:"nature"`
+	// Only set for Pokemon imported from a Showdown team
+	Ability string         `json:"ability,omitempty"`
+	Item    string         `json:"item,omitempty"`
+	EVs     map[string]int `json:"evs,omitempty"`
+	Moves   []string       `json:"moves,omitempty"`
+}
+
+// Nickname if the trainer gave one, species name otherwise
+func (r catchRecord) displayName() string {
+	if r.Nickname != "" {
+		return fmt.Sprintf("%s (%s)", r.Nickname, r.Species)
+	}
+	return r.Species
+}
+
+func (cfg *config) recordCatch(pokemon pokeapi.Pokemon) catchRecord {
+	if _, exists := cfg.caughtPokemon[pokemon.Name]; !exists {
+		cfg.caughtPokemon[pokemon.Name] = pokemon
+	}
+
+	ivs := make(map[string]int, len(pokemon.Stats))
+	for _, stat := range pokemon.Stats {
+		ivs[stat.Stat.Name] = cfg.intn(maxIV + 1)
+	}
+
+	cfg.nextCatchID++
+	record := catchRecord{
+		ID:       cfg.nextCatchID,
+		Species:  pokemon.Name,
+		CaughtAt: time.Now(),
+		Location: cfg.currentArea,
+		Level:    cfg.intn(maxCatchLevel) + 1,
+		IVs:      ivs,
+		Nature:   natures[cfg.intn(len(natures))],
+	}
+	cfg.catches = append(cfg.catches, record)
+	return record
+}
+
+func (cfg *config) timesCaught(species string) int {
+	count := 0
+	for _, record := range cfg.catches {
+		if record.Species == species {
+			count++
+		}
+	}
+	return count
+}
+
+func (cfg *config) catchesOf(species string) []catchRecord {
+	var records []catchRecord
+	for _, record := range cfg.catches {
+		if record.Species == species {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Finds an individual catch by its ID ("3" or "#3") or nickname
+func (cfg *config) findCatch(ref string) (*catchRecord, bool) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		for i := range cfg.catches {
+			if cfg.catches[i].ID == id {
+				return &cfg.catches[i], true
+			}
+		}
+		return nil, false
+	}
+	for i := range cfg.catches {
+		if cfg.catches[i].Nickname != "" && strings.EqualFold(cfg.catches[i].Nickname, ref) {
+			return &cfg.catches[i], true
+		}
+	}
+	return nil, false
+}
+
+// Like findCatch, but also accepts a species name or dex number, picking the
+// earliest catch of that species that satisfies eligible
+func (cfg *config) resolveCatch(ref string, eligible func(catchRecord) bool) (catchRecord, bool) {
+	if record, ok := cfg.findCatch(ref); ok {
+		return *record, eligible(*record)
+	}
+	pokemon, ok := cfg.findCaught(ref)
+	if !ok {
+		return catchRecord{}, false
+	}
+	for _, record := range cfg.catchesOf(pokemon.Name) {
+		if eligible(record) {
+			return record, true
+		}
+	}
+	return catchRecord{}, false
+}
+
+// Drops a catch; the species leaves the Pokedex once its last catch is gone
+func (cfg *config) releaseCatch(id int) (catchRecord, bool) {
+	for i, record := range cfg.catches {
+		if record.ID != id {
+			continue
+		}
+		cfg.catches = append(cfg.catches[:i], cfg.catches[i+1:]...)
+		cfg.removeFromParty(id)
+		cfg.removeFromBoxes(id)
+		if cfg.timesCaught(record.Species) == 0 {
+			delete(cfg.caughtPokemon, record.Species)
+		}
+		return record, true
+	}
+	return catchRecord{}, false
+}
+
+func (cfg *config) caughtSpeciesNames() []string {
+	names := make([]string, 0, len(cfg.caughtPokemon))
+	for name := range cfg.caughtPokemon {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (cfg *config) markSeen(name string) {
+	if name == "" {
+		return
+	}
+	if cfg.seen == nil {
+		cfg.seen = make(map[string]bool)
+	}
+	cfg.seen[name] = true
+}
+
+// Encounters name a Pokemon, which may be a form like basculin-red-striped;
+// seen is kept per species so forms count toward completion. A name that
+// can't be looked up is kept as it is.
+func (cfg *config) speciesOf(name string) string {
+	pokemon, ok := cfg.findCaught(name)
+	if !ok {
+		var err error
+		if pokemon, err = cfg.pokeClient.GetPokemonInfo(name); err != nil {
+			return name
+		}
+	}
+	if pokemon.Species.Name == "" {
+		return name
+	}
+	return pokemon.Species.Name
+}
+
+// Completion is tracked per species, so alternate forms count toward their
+// base species. Anything caught has also been seen.
+func (cfg *config) speciesProgress() (seen, caught map[string]bool) {
+	seen = make(map[string]bool, len(cfg.seen))
+	caught = make(map[string]bool, len(cfg.caughtPokemon))
+	for name := range cfg.seen {
+		seen[name] = true
+	}
+	for name, pokemon := range cfg.caughtPokemon {
+		species := pokemon.Species.Name
+		if species == "" {
+			species = name
+		}
+		caught[species] = true
+		seen[species] = true
+	}
+	return seen, caught
+}
+
+// Resolves any Pokemon, caught or not, preferring the stored snapshot. A zero
+// Pokemon with a nil error means the name didn't match and suggestions were
+// already printed.
+func (cfg *config) lookupPokemon(name string) (pokeapi.Pokemon, error) {
+	if pokemon, ok := cfg.findCaught(name); ok {
+		return pokemon, nil
+	}
+
+	pokemon, err := cfg.pokeClient.GetPokemonInfo(name)
+	if errors.Is(err, pokeapi.ErrNotFound) {
+		fmt.Printf("There is no Pokemon called '%s'.%s\n\n", name, didYouMean(cfg.pokemonSuggestions(name)))
+		return pokeapi.Pokemon{}, nil
+	}
+	if err != nil {
+		return pokeapi.Pokemon{}, fmt.Errorf("Error fetching Pokemon '%s': %w", name, err)
+	}
+	return pokemon, nil
+}
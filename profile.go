@@ -0,0 +1,239 @@
+package main
+
+// Trainer profiles let several people share one machine. Each profile is a
+// directory holding its own save file (Pokedex, party, boxes), catch log and
+// readline history, so nothing leaks from one trainer to the next.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	defaultProfile       = "default"
+	maxProfileNameLength = 32
+)
+
+func defaultProfilesDir() string {
+	return os.ExpandEnv("$HOME/.pokedexcli/profiles")
+}
+
+func profileSavePath(profilesDir, name string) string {
+	return filepath.Join(profilesDir, name, "save.json")
+}
+
+func profileHistoryPath(profilesDir, name string) string {
+	return filepath.Join(profilesDir, name, "history")
+}
+
+// Profile names become directory names, so keep them to a safe alphabet
+func validateProfileName(name string) error {
+	if name == "" || len(name) > maxProfileNameLength {
+		return fmt.Errorf("Profile names must be 1-%d characters long", maxProfileNameLength)
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("Invalid profile name '%s', use lowercase letters, digits, '-' and '_'", name)
+		}
+	}
+	return nil
+}
+
+// Before profiles existed there was a single save file and history file;
+// they become the default profile the first time a newer version starts
+func migrateLegacyProfile(profilesDir, legacySave, legacyHistory string) error {
+	moves := map[string]string{
+		legacySave:    profileSavePath(profilesDir, defaultProfile),
+		legacyHistory: profileHistoryPath(profilesDir, defaultProfile),
+	}
+	for from, to := range moves {
+		if _, err := os.Stat(from); err != nil {
+			continue
+		}
+		if _, err := os.Stat(to); err == nil {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+			return fmt.Errorf("Error creating profile directory: %w", err)
+		}
+		if err := os.Rename(from, to); err != nil {
+			return fmt.Errorf("Error moving %s into the default profile: %w", from, err)
+		}
+	}
+	return nil
+}
+
+func profileNames(profilesDir string) ([]string, error) {
+	entries, err := os.ReadDir(profilesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error reading profiles: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && validateProfileName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	return names, nil
+}
+
+// Points the config at a profile's save file and loads it, updating the
+// prompt and history when running interactively
+func (cfg *config) useProfile(name string) error {
+	cfg.profile = name
+	cfg.savePath = profileSavePath(cfg.profilesDir, name)
+	cfg.catchLogPath = profileCatchLogPath(cfg.profilesDir, name)
+	cfg.history = undoStack{}
+	if err := cfg.load(); err != nil {
+		return err
+	}
+	if cfg.rl != nil {
+		cfg.rl.SetPrompt(cfg.settings.prompt(name))
+		cfg.rl.SetHistoryPath(cfg.historyPath())
+	}
+	return nil
+}
+
+// A history file set in the config is shared by every profile
+func (cfg *config) historyPath() string {
+	if path := cfg.settings.get("history_file"); path != "" {
+		return expandHome(path)
+	}
+	return profileHistoryPath(cfg.profilesDir, cfg.profile)
+}
+
+func commandProfile(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return printProfiles(cfg)
+	}
+
+	switch args[0] {
+	case "list":
+		return printProfiles(cfg)
+	case "new":
+		if len(args) < 2 {
+			fmt.Printf("Please provide a name for the new profile\n\n")
+			return nil
+		}
+		return profileNew(cfg, args[1])
+	case "switch":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the name of the profile to switch to\n\n")
+			return nil
+		}
+		return profileSwitch(cfg, args[1])
+	case "delete":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the name of the profile to delete\n\n")
+			return nil
+		}
+		return profileDelete(cfg, args[1])
+	}
+	return fmt.Errorf("Unknown profile command '%s', expected list, new, switch or delete", args[0])
+}
+
+func printProfiles(cfg *config) error {
+	names, err := profileNames(cfg.profilesDir)
+	if err != nil {
+		return err
+	}
+	// The active profile has no directory until its first save
+	if !slices.Contains(names, cfg.profile) {
+		names = append(names, cfg.profile)
+		slices.Sort(names)
+	}
+
+	rows := [][]string{headings(cfg, []string{"", "PROFILE", "CATCHES"})}
+	for _, name := range names {
+		active, catches := "", len(cfg.catches)
+		if name == cfg.profile {
+			active = "*"
+		} else {
+			catches = savedCatchCount(profileSavePath(cfg.profilesDir, name))
+		}
+		rows = append(rows, []string{active, name, fmt.Sprint(catches)})
+	}
+	printTable(rows, 2)
+	fmt.Println()
+	return nil
+}
+
+// Best effort: an unreadable save just shows as empty in the listing
+func savedCatchCount(path string) int {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	var data saveData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return 0
+	}
+	return len(data.Catches)
+}
+
+func profileNew(cfg *config, name string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(cfg.profilesDir, name)); err == nil || name == cfg.profile {
+		return fmt.Errorf("Profile '%s' already exists", name)
+	}
+	if err := os.MkdirAll(filepath.Join(cfg.profilesDir, name), 0o755); err != nil {
+		return fmt.Errorf("Error creating profile '%s': %w", name, err)
+	}
+	fmt.Printf("Created profile '%s'\n", name)
+	return profileSwitch(cfg, name)
+}
+
+func profileSwitch(cfg *config, name string) error {
+	if name == cfg.profile {
+		fmt.Printf("You are already using profile '%s'\n\n", name)
+		return nil
+	}
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(cfg.profilesDir, name)); err != nil {
+		return fmt.Errorf("Profile '%s' doesn't exist, create it with 'profile new %s'", name, name)
+	}
+
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	previous := cfg.profile
+	if err := cfg.useProfile(name); err != nil {
+		// Go back rather than leave the other profile's Pokedex pointed at this save file
+		if restoreErr := cfg.useProfile(previous); restoreErr != nil {
+			return errors.Join(err, restoreErr)
+		}
+		return err
+	}
+	fmt.Printf("Switched to profile '%s' (%d species caught)\n\n", name, len(cfg.caughtSpeciesNames()))
+	return nil
+}
+
+func profileDelete(cfg *config, name string) error {
+	if name == cfg.profile {
+		return fmt.Errorf("Can't delete the active profile, switch to another one first")
+	}
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	dir := filepath.Join(cfg.profilesDir, name)
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("Profile '%s' doesn't exist", name)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("Error deleting profile '%s': %w", name, err)
+	}
+	fmt.Printf("Deleted profile '%s'\n\n", name)
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestProfiles(t *testing.T) {
+	cfg := &config{caughtPokemon: make(map[string]pokeapi.Pokemon), profilesDir: t.TempDir()}
+	if err := cfg.useProfile(defaultProfile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+	cfg.party = []int{1}
+
+	if err := commandProfile(cfg, "new", "misty"); err != nil {
+		t.Fatalf("Unexpected error creating: %v", err)
+	}
+	if cfg.profile != "misty" || len(cfg.catches) != 0 || len(cfg.party) != 0 {
+		t.Fatalf("Expected an empty misty profile, got %s with %d catches", cfg.profile, len(cfg.catches))
+	}
+	if err := commandProfile(cfg, "new", "misty"); err == nil {
+		t.Error("Expected creating an existing profile to fail")
+	}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 120, Name: "staryu"})
+	if err := cfg.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := commandProfile(cfg, "switch", defaultProfile); err != nil {
+		t.Fatalf("Unexpected error switching: %v", err)
+	}
+	if _, ok := cfg.caughtPokemon["pikachu"]; !ok || len(cfg.party) != 1 {
+		t.Errorf("Expected the default profile's Pokedex back, got %v", cfg.caughtSpeciesNames())
+	}
+	if _, ok := cfg.caughtPokemon["staryu"]; ok {
+		t.Error("Expected catches to stay in the misty profile")
+	}
+	if err := commandProfile(cfg, "switch", "brock"); err == nil {
+		t.Error("Expected switching to a missing profile to fail")
+	}
+
+	if err := commandProfile(cfg, "delete", defaultProfile); err == nil {
+		t.Error("Expected deleting the active profile to fail")
+	}
+	if err := commandProfile(cfg, "delete", "misty"); err != nil {
+		t.Fatalf("Unexpected error deleting: %v", err)
+	}
+	if names, _ := profileNames(cfg.profilesDir); len(names) != 1 || names[0] != defaultProfile {
+		t.Errorf("Expected only the default profile left, got %v", names)
+	}
+}
+
+func TestValidateProfileName(t *testing.T) {
+	tests := []struct {
+		name  string
+		valid bool
+	}{
+		{name: "ash", valid: true},
+		{name: "team_rocket-2", valid: true},
+		{name: "", valid: false},
+		{name: "../escape", valid: false},
+		{name: "Ash", valid: false},
+		{name: "a-very-long-profile-name-that-goes-on", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := validateProfileName(test.name); (err == nil) != test.valid {
+				t.Errorf("Expected valid=%v, got %v", test.valid, err)
+			}
+		})
+	}
+}
+
+func TestMigrateLegacyProfile(t *testing.T) {
+	home := t.TempDir()
+	profilesDir := filepath.Join(home, "profiles")
+	legacySave := filepath.Join(home, "save.json")
+	legacyHistory := filepath.Join(home, "history")
+	if err := os.WriteFile(legacySave, []byte(`{"next_catch_id": 3}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := migrateLegacyProfile(profilesDir, legacySave, legacyHistory); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(legacySave); err == nil {
+		t.Error("Expected the legacy save to be moved")
+	}
+	cfg := &config{profilesDir: profilesDir}
+	if err := cfg.useProfile(defaultProfile); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.nextCatchID != 3 {
+		t.Errorf("Expected the legacy save in the default profile, got next ID %d", cfg.nextCatchID)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+// Building the stat rankings fetches every Pokemon, so the result is kept on
+// disk and shared by all profiles. Base stats rarely change, a week is plenty;
+// rankings with skipped Pokemon are only kept long enough to avoid refetching
+// on every inspect.
+const (
+	statAggregateMaxAge           = 7 * 24 * time.Hour
+	incompleteStatAggregateMaxAge = time.Hour
+)
+
+func defaultRankingsPath() string {
+	return os.ExpandEnv("$HOME/.pokedexcli/rankings.json")
+}
+
+type savedStatAggregate struct {
+	BuiltAt   time.Time             `json:"built_at"`
+	Aggregate pokeapi.StatAggregate `json:"aggregate"`
+}
+
+func (s savedStatAggregate) maxAge() time.Duration {
+	if s.Aggregate.Skipped > 0 {
+		return incompleteStatAggregateMaxAge
+	}
+	return statAggregateMaxAge
+}
+
+// Returns the rankings saved on disk while they're fresh, otherwise builds
+// them again and saves the result. A config without a path (as in tests)
+// always asks the client.
+func (cfg *config) statAggregate(ctx context.Context) (pokeapi.StatAggregate, error) {
+	if saved, ok := readStatAggregate(cfg.rankingsPath); ok && time.Since(saved.BuiltAt) < saved.maxAge() {
+		return saved.Aggregate, nil
+	}
+
+	aggregate, err := cfg.pokeClient.GetStatAggregate(ctx)
+	if err != nil {
+		return pokeapi.StatAggregate{}, err
+	}
+	if err := writeStatAggregate(cfg.rankingsPath, savedStatAggregate{BuiltAt: time.Now(), Aggregate: aggregate}); err != nil {
+		fmt.Printf("⚠️ %v\n", err)
+	}
+	return aggregate, nil
+}
+
+// A missing or unreadable file just means the rankings get rebuilt
+func readStatAggregate(path string) (savedStatAggregate, bool) {
+	if path == "" {
+		return savedStatAggregate{}, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return savedStatAggregate{}, false
+	}
+	var saved savedStatAggregate
+	if err := json.Unmarshal(data, &saved); err != nil || len(saved.Aggregate.Stats) == 0 {
+		return savedStatAggregate{}, false
+	}
+	return saved, true
+}
+
+func writeStatAggregate(path string, saved savedStatAggregate) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("Error encoding stat rankings: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("Error creating stat rankings directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("Error writing stat rankings: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("Error writing stat rankings: %w", err)
+	}
+	return nil
+}
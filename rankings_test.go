@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestStatAggregateIsSavedToDisk(t *testing.T) {
+	fetches := 0
+	client := &mockClient{
+		getStatAggregateFunc: func() (pokeapi.StatAggregate, error) {
+			fetches++
+			return pokeapi.StatAggregate{Stats: map[string][]int{"speed": {50, 90}}}, nil
+		},
+	}
+	path := filepath.Join(t.TempDir(), "rankings.json")
+
+	cfg := &config{pokeClient: client, rankingsPath: path}
+	if _, err := cfg.statAggregate(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A new session (or one whose API cache was cleared) reads the saved copy
+	restarted := &config{pokeClient: client, rankingsPath: path}
+	aggregate, err := restarted.statAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected the rankings to be fetched once, got %d", fetches)
+	}
+	if len(aggregate.Stats["speed"]) != 2 {
+		t.Errorf("Expected the saved speed stats, got %v", aggregate.Stats)
+	}
+}
+
+func TestStaleStatAggregateIsRebuilt(t *testing.T) {
+	fetches := 0
+	client := &mockClient{
+		getStatAggregateFunc: func() (pokeapi.StatAggregate, error) {
+			fetches++
+			return pokeapi.StatAggregate{Stats: map[string][]int{"speed": {50}}}, nil
+		},
+	}
+	path := filepath.Join(t.TempDir(), "rankings.json")
+	stale := savedStatAggregate{
+		BuiltAt:   time.Now().Add(-statAggregateMaxAge - time.Hour),
+		Aggregate: pokeapi.StatAggregate{Stats: map[string][]int{"speed": {10}}},
+	}
+	if err := writeStatAggregate(path, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config{pokeClient: client, rankingsPath: path}
+	if _, err := cfg.statAggregate(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Errorf("Expected stale rankings to be rebuilt, got %d fetches", fetches)
+	}
+	if saved, ok := readStatAggregate(path); !ok || saved.Aggregate.Stats["speed"][0] != 50 {
+		t.Errorf("Expected the rebuilt rankings to be saved, got %+v", saved)
+	}
+}
+
+func TestIncompleteStatAggregateExpiresSooner(t *testing.T) {
+	fetches := 0
+	client := &mockClient{
+		getStatAggregateFunc: func() (pokeapi.StatAggregate, error) {
+			fetches++
+			return pokeapi.StatAggregate{Stats: map[string][]int{"speed": {50, 90}}}, nil
+		},
+	}
+	path := filepath.Join(t.TempDir(), "rankings.json")
+	incomplete := savedStatAggregate{
+		BuiltAt:   time.Now().Add(-2 * incompleteStatAggregateMaxAge),
+		Aggregate: pokeapi.StatAggregate{Stats: map[string][]int{"speed": {50}}, Skipped: 1},
+	}
+	if err := writeStatAggregate(path, incomplete); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config{pokeClient: client, rankingsPath: path}
+	aggregate, err := cfg.statAggregate(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fetches != 1 || aggregate.Skipped != 0 {
+		t.Errorf("Expected rankings with skipped Pokemon to be rebuilt, got %d fetches", fetches)
+	}
+}
@@ -1,10 +1,73 @@
 package main
 
 import (
+	"slices"
 	"strings"
+	"unicode"
 )
 
 func cleanInput(text string) []string {
 	splitText := strings.Fields(strings.ToLower(text))
 	return splitText
 }
+
+// Splits a line on whitespace like cleanInput but keeps its case, and keeps
+// "double quoted" text together as one argument, spaces included
+func splitArgs(text string) []string {
+	args := []string{}
+	var current strings.Builder
+	inArg, quoted := false, false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			inArg = true
+		case unicode.IsSpace(r) && !quoted:
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+func firstArg(args []string) string {
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
+// Separates --flags from positional arguments. Flags named in valued consume
+// the next argument (or an inline --flag=value); all others are booleans.
+func splitFlags(args []string, valued ...string) ([]string, map[string]string) {
+	positional := []string{}
+	flags := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !hasValue && slices.Contains(valued, name) && i+1 < len(args) {
+			i++
+			value = args[i]
+			hasValue = true
+		}
+		if !hasValue {
+			value = "true"
+		}
+		flags[name] = value
+	}
+	return positional, flags
+}
@@ -1,6 +1,30 @@
 package main
 
-import "testing"
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "  rename 1   Sparky ", expected: []string{"rename", "1", "Sparky"}},
+		{input: `config set prompt "Dex {profile} > "`, expected: []string{"config", "set", "prompt", "Dex {profile} > "}},
+		{input: `config set prompt "a  b"c`, expected: []string{"config", "set", "prompt", "a  bc"}},
+		{input: `config set prompt ""`, expected: []string{"config", "set", "prompt", ""}},
+		{input: `export "my dex.csv`, expected: []string{"export", "my dex.csv"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			if actual := splitArgs(test.input); !slices.Equal(actual, test.expected) {
+				t.Errorf("Expected %q, got %q", test.expected, actual)
+			}
+		})
+	}
+}
 
 func TestCleanInput(t *testing.T) {
 	tests := []struct {
@@ -54,3 +78,55 @@ func TestCleanInput(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitFlags(t *testing.T) {
+	tests := []struct {
+		name               string
+		input              []string
+		valued             []string
+		expectedPositional []string
+		expectedFlags      map[string]string
+	}{
+		{
+			name:               "boolean flag after positional",
+			input:              []string{"pikachu", "--moves"},
+			expectedPositional: []string{"pikachu"},
+			expectedFlags:      map[string]string{"moves": "true"},
+		},
+		{
+			name:               "valued flag",
+			input:              []string{"--sort", "name", "--type", "fire"},
+			valued:             []string{"sort", "type"},
+			expectedPositional: []string{},
+			expectedFlags:      map[string]string{"sort": "name", "type": "fire"},
+		},
+		{
+			name:               "inline value",
+			input:              []string{"--min-stat=attack=80", "extra"},
+			expectedPositional: []string{"extra"},
+			expectedFlags:      map[string]string{"min-stat": "attack=80"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			positional, flags := splitFlags(test.input, test.valued...)
+			if len(positional) != len(test.expectedPositional) {
+				t.Fatalf("Expected positional %v, got %v", test.expectedPositional, positional)
+			}
+			for i := range positional {
+				if positional[i] != test.expectedPositional[i] {
+					t.Errorf("Expected positional %v, got %v", test.expectedPositional, positional)
+				}
+			}
+			if len(flags) != len(test.expectedFlags) {
+				t.Fatalf("Expected flags %v, got %v", test.expectedFlags, flags)
+			}
+			for name, value := range test.expectedFlags {
+				if flags[name] != value {
+					t.Errorf("Expected --%s=%s, got %s", name, value, flags[name])
+				}
+			}
+		})
+	}
+}
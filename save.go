@@ -0,0 +1,96 @@
+package main
+
+// Persists the Pokedex between sessions so caught Pokemon survive a restart
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+type saveData struct {
+	CaughtPokemon map[string]pokeapi.Pokemon `json:"caught_pokemon"`
+	Catches       []catchRecord              `json:"catches"`
+	NextCatchID   int                        `json:"next_catch_id"`
+	Seen          map[string]bool            `json:"seen"`
+	Party         []int                      `json:"party"`
+	Boxes         []pcBox                    `json:"boxes"`
+}
+
+func (cfg *config) snapshot() saveData {
+	return saveData{
+		CaughtPokemon: cfg.caughtPokemon,
+		Catches:       cfg.catches,
+		NextCatchID:   cfg.nextCatchID,
+		Seen:          cfg.seen,
+		Party:         cfg.party,
+		Boxes:         cfg.boxes,
+	}
+}
+
+func (cfg *config) restore(data saveData) {
+	cfg.caughtPokemon = data.CaughtPokemon
+	if cfg.caughtPokemon == nil {
+		cfg.caughtPokemon = make(map[string]pokeapi.Pokemon)
+	}
+	cfg.catches = data.Catches
+	cfg.nextCatchID = data.NextCatchID
+	cfg.seen = data.Seen
+	if cfg.seen == nil {
+		cfg.seen = make(map[string]bool)
+	}
+	cfg.party = data.Party
+	cfg.boxes = data.Boxes
+}
+
+// A config without a save path (as in tests) keeps everything in memory
+func (cfg *config) save() error {
+	if cfg.savePath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cfg.snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error encoding save data: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.savePath), 0o755); err != nil {
+		return fmt.Errorf("Error creating save directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a half-written save
+	tmp := cfg.savePath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("Error writing save file: %w", err)
+	}
+	if err := os.Rename(tmp, cfg.savePath); err != nil {
+		return fmt.Errorf("Error writing save file: %w", err)
+	}
+	return nil
+}
+
+func (cfg *config) load() error {
+	if cfg.savePath == "" {
+		return nil
+	}
+
+	raw, err := os.ReadFile(cfg.savePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		cfg.restore(saveData{})
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error reading save file: %w", err)
+	}
+
+	var data saveData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("Error decoding save file %s: %w", cfg.savePath, err)
+	}
+	cfg.restore(data)
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+
+	cfg := &config{
+		caughtPokemon: map[string]pokeapi.Pokemon{"pikachu": {ID: 25, Name: "pikachu"}},
+		catches:       []catchRecord{{ID: 1, Species: "pikachu"}, {ID: 2, Species: "pikachu", Nickname: "Sparky"}},
+		nextCatchID:   2,
+		savePath:      path,
+	}
+	if err := cfg.save(); err != nil {
+		t.Fatalf("Unexpected error saving: %v", err)
+	}
+
+	loaded := &config{savePath: path}
+	if err := loaded.load(); err != nil {
+		t.Fatalf("Unexpected error loading: %v", err)
+	}
+	if loaded.caughtPokemon["pikachu"].ID != 25 {
+		t.Errorf("Expected pikachu to be restored, got %v", loaded.caughtPokemon)
+	}
+	if loaded.timesCaught("pikachu") != 2 {
+		t.Errorf("Expected count 2, got %d", loaded.timesCaught("pikachu"))
+	}
+	if record, ok := loaded.findCatch("sparky"); !ok || record.ID != 2 {
+		t.Errorf("Expected to find #2 by nickname, got %v", record)
+	}
+}
+
+func TestClearIsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	cfg := &config{
+		pokeClient:    &mockClient{},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+		savePath:      path,
+	}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+	cfg.party = []int{1}
+	if err := cfg.save(); err != nil {
+		t.Fatalf("Unexpected error saving: %v", err)
+	}
+
+	if err := commandClear(cfg); err != nil {
+		t.Fatalf("Unexpected error clearing: %v", err)
+	}
+
+	// The Pokedex must stay cleared after a restart
+	loaded := &config{savePath: path}
+	if err := loaded.load(); err != nil {
+		t.Fatalf("Unexpected error loading: %v", err)
+	}
+	if len(loaded.caughtPokemon) != 0 || len(loaded.catches) != 0 || len(loaded.party) != 0 {
+		t.Errorf("Expected an empty Pokedex after clear, got %d catches", len(loaded.catches))
+	}
+	// nextCatchID holds the last ID handed out; keeping it means IDs aren't reused
+	if loaded.nextCatchID != 1 {
+		t.Errorf("Expected the last catch ID 1 to be kept after clear, got %d", loaded.nextCatchID)
+	}
+}
+
+func TestLoadMissingSave(t *testing.T) {
+	cfg := &config{savePath: filepath.Join(t.TempDir(), "missing.json")}
+	if err := cfg.load(); err != nil {
+		t.Fatalf("Expected a missing save to start an empty Pokedex, got %v", err)
+	}
+	if cfg.caughtPokemon == nil {
+		t.Error("Expected an empty Pokedex after loading a missing save")
+	}
+}
@@ -0,0 +1,422 @@
+package main
+
+// CLI settings come from, in increasing priority: built-in defaults, the
+// config file (~/.config/pokedexcli/config.toml), environment variables and
+// command-line flags. The file is a small subset of TOML: one `key = value` per
+// line, # comments, quoted strings, and bare booleans.
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const (
+	sourceDefault = "default"
+	sourceFile    = "file"
+	sourceEnv     = "env"
+	sourceFlag    = "flag"
+)
+
+type settingKind int
+
+const (
+	settingString settingKind = iota
+	settingBool
+	settingDuration
+)
+
+type settingDef struct {
+	key          string
+	env          string
+	kind         settingKind
+	defaultValue string
+	// When set, the value must be one of these
+	choices     []string
+	description string
+}
+
+var settingDefs = []settingDef{
+	{key: "cache", env: "USE_CACHE", kind: settingBool, defaultValue: "true", description: "Cache PokeAPI responses"},
+	{key: "timeout", env: "POKEDEX_TIMEOUT", kind: settingDuration, defaultValue: "5s", description: "HTTP timeout for PokeAPI requests"},
+	{key: "cache_ttl", env: "POKEDEX_CACHE_TTL", kind: settingDuration, defaultValue: "5m", description: "How long cached responses stay fresh"},
+	{key: "cache_cleanup", env: "POKEDEX_CACHE_CLEANUP", kind: settingDuration, defaultValue: "10m", description: "How often expired cache entries are removed"},
+	{key: "api_url", env: "POKEDEX_API_URL", defaultValue: pokeapi.DefaultBaseURL, description: "Base URL of the PokeAPI instance to use"},
+	{key: "history_file", env: "POKEDEX_HISTORY_FILE", defaultValue: "", description: "Command history file (empty: one per profile)"},
+	{key: "prompt", env: "POKEDEX_PROMPT", defaultValue: "Pokedex [{profile}] > ", description: "Prompt, {profile} is replaced by the profile name"},
+	{key: "output_format", env: "POKEDEX_OUTPUT", defaultValue: "text", choices: []string{"text", "json"}, description: "Output of pokedex and stats"},
+	{key: "color", env: "POKEDEX_COLOR", defaultValue: "auto", choices: []string{"auto", "always", "never"}, description: "Colored output (auto respects NO_COLOR and pipes)"},
+	{key: "theme", env: "POKEDEX_THEME", defaultValue: "default", description: "Color theme"},
+}
+
+// Settings that commands read while running; the rest only matter at startup
+var liveSettings = []string{"prompt", "output_format", "color", "theme"}
+
+type settings struct {
+	path    string
+	values  map[string]string
+	sources map[string]string
+}
+
+func defaultSettingsPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "pokedexcli", "config.toml")
+	}
+	return os.ExpandEnv("$HOME/.config/pokedexcli/config.toml")
+}
+
+func findSetting(key string) (settingDef, bool) {
+	for _, def := range settingDefs {
+		if def.key == key {
+			return def, true
+		}
+	}
+	return settingDef{}, false
+}
+
+func settingKeys() []string {
+	keys := make([]string, 0, len(settingDefs))
+	for _, def := range settingDefs {
+		keys = append(keys, def.key)
+	}
+	return keys
+}
+
+// Validates a value and returns it in canonical form ("FALSE" -> "false")
+func normalizeSetting(def settingDef, value string) (string, error) {
+	switch def.kind {
+	case settingBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("Invalid value '%s' for %s, expected true or false", value, def.key)
+		}
+		return strconv.FormatBool(b), nil
+	case settingDuration:
+		d, err := time.ParseDuration(value)
+		if err != nil || d <= 0 {
+			return "", fmt.Errorf("Invalid value '%s' for %s, expected a duration like 5s or 10m", value, def.key)
+		}
+		return d.String(), nil
+	}
+	if def.key == "api_url" {
+		if u, err := url.Parse(value); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "", fmt.Errorf("Invalid value '%s' for api_url, expected an http(s) URL", value)
+		}
+	}
+	if def.key == "theme" && !slices.Contains(themeNames(), value) {
+		return "", fmt.Errorf("Unknown theme '%s', expected one of: %s", value, strings.Join(themeNames(), ", "))
+	}
+	if def.choices != nil && !slices.Contains(def.choices, value) {
+		return "", fmt.Errorf("Invalid value '%s' for %s, expected one of: %s", value, def.key, strings.Join(def.choices, ", "))
+	}
+	return value, nil
+}
+
+func (s *settings) set(key, value, source string) error {
+	def, ok := findSetting(key)
+	if !ok {
+		return fmt.Errorf("Unknown setting '%s', expected one of: %s", key, strings.Join(settingKeys(), ", "))
+	}
+	value, err := normalizeSetting(def, value)
+	if err != nil {
+		return err
+	}
+	if s.values == nil {
+		s.values = make(map[string]string)
+		s.sources = make(map[string]string)
+	}
+	s.values[key] = value
+	s.sources[key] = source
+	return nil
+}
+
+// Falls back to the default, so a zero settings (as in tests) behaves like a
+// fresh install
+func (s settings) get(key string) string {
+	if value, ok := s.values[key]; ok {
+		return value
+	}
+	def, _ := findSetting(key)
+	return def.defaultValue
+}
+
+func (s settings) source(key string) string {
+	if source, ok := s.sources[key]; ok {
+		return source
+	}
+	return sourceDefault
+}
+
+func (s settings) bool(key string) bool {
+	return s.get(key) == "true"
+}
+
+func (s settings) duration(key string) time.Duration {
+	d, _ := time.ParseDuration(s.get(key))
+	return d
+}
+
+func (s settings) useColor() bool {
+	switch s.get("color") {
+	case "always":
+		return true
+	case "never":
+		return false
+	}
+	return colorEnabled()
+}
+
+func (s settings) prompt(profile string) string {
+	return strings.ReplaceAll(s.get("prompt"), "{profile}", profile)
+}
+
+// Loads the config file (a missing one is fine) and then the environment.
+// Problems in the file are returned alongside settings that are still usable.
+func loadSettings(path string) (settings, error) {
+	s := settings{path: path}
+	var errs []error
+
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		errs = append(errs, fmt.Errorf("Error reading %s: %w", path, err))
+	}
+	if err == nil {
+		values, err := parseTOML(string(raw))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("Error parsing %s: %w", path, err))
+		}
+		for _, key := range sortedKeys(values) {
+			if err := s.set(key, values[key], sourceFile); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			}
+		}
+	}
+
+	for _, def := range settingDefs {
+		if value, ok := os.LookupEnv(def.env); ok {
+			if def.env == "USE_CACHE" {
+				// Kept from before settings existed: only FALSE turns the cache off
+				value = strconv.FormatBool(value != "FALSE")
+			}
+			if err := s.set(def.key, value, sourceEnv); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", def.env, err))
+			}
+		}
+	}
+	return s, errors.Join(errs...)
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Parses `key = value` lines, returning every value as its string form.
+// Tables, arrays and multi-line strings aren't supported.
+func parseTOML(text string) (map[string]string, error) {
+	values := make(map[string]string)
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n+1)
+		}
+		key = strings.TrimSpace(key)
+		parsed, err := parseTOMLValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		values[key] = parsed
+	}
+	return values, nil
+}
+
+func parseTOMLValue(value string) (string, error) {
+	if strings.HasPrefix(value, `"`) {
+		// A closing quote followed by a comment is fine
+		end := strings.LastIndex(value, `"`)
+		if end > 0 {
+			rest := strings.TrimSpace(value[end+1:])
+			if rest == "" || strings.HasPrefix(rest, "#") {
+				return strconv.Unquote(value[:end+1])
+			}
+		}
+		return "", fmt.Errorf("invalid string %s", value)
+	}
+	if i := strings.Index(value, "#"); i != -1 {
+		value = strings.TrimSpace(value[:i])
+	}
+	if value == "true" || value == "false" {
+		return value, nil
+	}
+	if _, err := strconv.Atoi(value); err == nil {
+		return value, nil
+	}
+	return "", fmt.Errorf("invalid value '%s' (strings need quotes)", value)
+}
+
+func formatTOMLValue(def settingDef, value string) string {
+	if def.kind == settingBool {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// Rewrites or appends one key in the config file, keeping everything else
+// (comments included) as it was
+func writeSetting(path, key, line string) error {
+	raw, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+
+	var lines []string
+	if len(raw) > 0 {
+		lines = strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+	}
+	replaced := false
+	for i, existing := range lines {
+		if name, _, ok := strings.Cut(existing, "="); ok && strings.TrimSpace(name) == key {
+			lines[i] = line
+			replaced = true
+		}
+	}
+	if !replaced {
+		lines = append(lines, line)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("Error creating config directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		return fmt.Errorf("Error writing %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("Error writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *config) jsonOutput() bool {
+	return cfg.settings.get("output_format") == "json"
+}
+
+func printJSON(v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Error encoding output: %w", err)
+	}
+	fmt.Printf("%s\n", data)
+	return nil
+}
+
+func commandConfig(cfg *config, args ...string) error {
+	if len(args) == 0 {
+		return printSettings(cfg)
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "list":
+		return printSettings(cfg)
+	case "get":
+		if len(args) < 2 {
+			fmt.Printf("Please provide the setting to show (%s)\n\n", strings.Join(settingKeys(), ", "))
+			return nil
+		}
+		key := strings.ToLower(args[1])
+		if _, ok := findSetting(key); !ok {
+			return fmt.Errorf("Unknown setting '%s', expected one of: %s", key, strings.Join(settingKeys(), ", "))
+		}
+		fmt.Printf("%s = %q (%s)\n\n", key, cfg.settings.get(key), cfg.settings.source(key))
+		return nil
+	case "set":
+		if len(args) < 3 {
+			fmt.Printf("Please provide the setting and its new value\n\n")
+			return nil
+		}
+		return configSet(cfg, strings.ToLower(args[1]), strings.Join(args[2:], " "))
+	}
+	return fmt.Errorf("Unknown config command '%s', expected list, get or set", args[0])
+}
+
+func printSettings(cfg *config) error {
+	fmt.Printf("Config file: %s\n\n", cfg.settings.path)
+	rows := [][]string{headings(cfg, []string{"SETTING", "VALUE", "SOURCE", "DESCRIPTION"})}
+	for _, def := range settingDefs {
+		source := cfg.settings.source(def.key)
+		if source == sourceEnv {
+			source = def.env
+		}
+		rows = append(rows, []string{def.key, strconv.Quote(cfg.settings.get(def.key)), source, def.description})
+	}
+	printTable(rows)
+	fmt.Println()
+	return nil
+}
+
+func configSet(cfg *config, key, value string) error {
+	def, ok := findSetting(key)
+	if !ok {
+		return fmt.Errorf("Unknown setting '%s', expected one of: %s", key, strings.Join(settingKeys(), ", "))
+	}
+	value, err := normalizeSetting(def, value)
+	if err != nil {
+		return err
+	}
+	if cfg.settings.path == "" {
+		return fmt.Errorf("No config file to write to")
+	}
+	if err := writeSetting(cfg.settings.path, key, fmt.Sprintf("%s = %s", key, formatTOMLValue(def, value))); err != nil {
+		return err
+	}
+	fmt.Printf("Saved %s = %q to %s\n", key, value, cfg.settings.path)
+
+	switch cfg.settings.source(key) {
+	case sourceEnv:
+		fmt.Printf("%s is set in your environment and still overrides it\n\n", def.env)
+		return nil
+	case sourceFlag:
+		fmt.Printf("A command-line flag still overrides it for this session\n\n")
+		return nil
+	}
+	if err := cfg.settings.set(key, value, sourceFile); err != nil {
+		return err
+	}
+	if !slices.Contains(liveSettings, key) {
+		fmt.Printf("This takes effect the next time you start the Pokedex\n\n")
+		return nil
+	}
+	fmt.Println()
+	return cfg.applySettings()
+}
+
+// Brings the running session in line with settings that can change live
+func (cfg *config) applySettings() error {
+	out, err := newRenderer(cfg.settings.get("theme"), cfg.settings.useColor())
+	if err != nil {
+		return err
+	}
+	cfg.out = out
+	if cfg.rl != nil {
+		cfg.rl.SetPrompt(cfg.settings.prompt(cfg.profile))
+	}
+	return nil
+}
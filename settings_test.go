@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expected      map[string]string
+		expectedError bool
+	}{
+		{
+			name:     "values and comments",
+			input:    "# settings\ncache = false\ntimeout = \"10s\" # slow network\nprompt = \"Dex \\\"{profile}\\\" > \"\n",
+			expected: map[string]string{"cache": "false", "timeout": "10s", "prompt": `Dex "{profile}" > `},
+		},
+		{name: "unquoted string", input: "theme = pastel\n", expectedError: true},
+		{name: "missing value", input: "cache\n", expectedError: true},
+		{name: "unterminated string", input: "prompt = \"oops\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := parseTOML(test.input)
+			if test.expectedError {
+				if err == nil {
+					t.Errorf("Expected an error, got %v", values)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for key, expected := range test.expected {
+				if values[key] != expected {
+					t.Errorf("Expected %s = %q, got %q", key, expected, values[key])
+				}
+			}
+		})
+	}
+}
+
+func TestLoadSettingsPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte("timeout = \"10s\"\ncache_ttl = \"1m\"\ntheme = \"pastel\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("POKEDEX_CACHE_TTL", "2m")
+	t.Setenv("USE_CACHE", "FALSE")
+
+	settings, err := loadSettings(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := settings.set("theme", "mono", sourceFlag); err != nil {
+		t.Fatal(err)
+	}
+
+	if settings.duration("timeout") != 10*time.Second || settings.source("timeout") != sourceFile {
+		t.Errorf("Expected the file's timeout, got %v from %s", settings.duration("timeout"), settings.source("timeout"))
+	}
+	if settings.duration("cache_ttl") != 2*time.Minute || settings.source("cache_ttl") != sourceEnv {
+		t.Errorf("Expected the environment to override the file, got %v", settings.duration("cache_ttl"))
+	}
+	if settings.bool("cache") {
+		t.Error("Expected USE_CACHE=FALSE to disable the cache")
+	}
+	if settings.get("theme") != "mono" {
+		t.Errorf("Expected the flag to override the file, got %s", settings.get("theme"))
+	}
+	if settings.duration("cache_cleanup") != 10*time.Minute || settings.source("cache_cleanup") != sourceDefault {
+		t.Errorf("Expected the default cleanup interval, got %v", settings.duration("cache_cleanup"))
+	}
+}
+
+func TestUseCacheEnv(t *testing.T) {
+	// Anything but FALSE keeps the cache on, as it did before settings existed
+	tests := map[string]bool{"FALSE": false, "false": true, "0": true, "no": true, "TRUE": true, "": true}
+
+	for value, expected := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Setenv("USE_CACHE", value)
+			settings, err := loadSettings(filepath.Join(t.TempDir(), "config.toml"))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if settings.bool("cache") != expected {
+				t.Errorf("Expected USE_CACHE=%q to give cache %v", value, expected)
+			}
+		})
+	}
+}
+
+func TestSettingValidation(t *testing.T) {
+	var settings settings
+	for key, value := range map[string]string{
+		"timeout":       "soon",
+		"cache":         "maybe",
+		"output_format": "xml",
+		"theme":         "neon",
+		"volume":        "11",
+	} {
+		if err := settings.set(key, value, sourceFlag); err == nil {
+			t.Errorf("Expected %s = %s to be rejected", key, value)
+		}
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedexcli", "config.toml")
+	cfg := &config{settings: settings{path: path}}
+
+	if err := commandConfig(cfg, "set", "timeout", "30s"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := commandConfig(cfg, "set", "prompt", "Dex", "{profile}", ">"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := commandConfig(cfg, "set", "timeout", "45s"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := commandConfig(cfg, "set", "timeout", "forever"); err == nil {
+		t.Error("Expected an invalid value to be rejected")
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(raw); got != "timeout = \"45s\"\nprompt = \"Dex {profile} >\"\n" {
+		t.Errorf("Unexpected config file:\n%s", got)
+	}
+	// Quoted on the command line, the value keeps its spaces
+	if err := commandConfig(cfg, "set", "prompt", "Dex  {profile} > "); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reloaded, err := loadSettings(path); err != nil || reloaded.prompt("ash") != "Dex  ash > " {
+		t.Errorf("Expected the saved prompt to keep its spaces, got %q (%v)", reloaded.prompt("ash"), err)
+	}
+	if err := commandConfig(cfg, "set", "prompt", "Dex", "{profile}", ">"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.settings.prompt("ash") != "Dex ash >" {
+		t.Errorf("Expected the prompt to apply immediately, got %q", cfg.settings.prompt("ash"))
+	}
+
+	reloaded, err := loadSettings(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if reloaded.duration("timeout") != 45*time.Second {
+		t.Errorf("Expected the saved timeout, got %v", reloaded.duration("timeout"))
+	}
+	if !strings.Contains(reloaded.get("prompt"), "{profile}") {
+		t.Errorf("Expected the saved prompt, got %q", reloaded.get("prompt"))
+	}
+}
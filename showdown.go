@@ -0,0 +1,385 @@
+package main
+
+// Reads and writes teams in Pokemon Showdown's text format, e.g.
+//
+//	Sparky (Pikachu) @ Light Ball
+//	Ability: Static
+//	Level: 50
+//	EVs: 252 Atk / 4 SpD / 252 Spe
+//	Jolly Nature
+//	IVs: 0 SpA
+//	- Volt Tackle
+//	- Iron Tail
+//
+// Sets are separated by blank lines. Names are stored the PokeAPI way
+// ("volt-tackle") and only turned into display names on export; Showdown
+// ignores case and punctuation when it reads them back.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const (
+	showdownLevel  = 100
+	showdownNature = "serious"
+	maxEV          = 252
+	maxTotalEVs    = 510
+)
+
+var showdownStats = map[string]string{
+	"hp":              "HP",
+	"attack":          "Atk",
+	"defense":         "Def",
+	"special-attack":  "SpA",
+	"special-defense": "SpD",
+	"speed":           "Spe",
+}
+
+type showdownSet struct {
+	Nickname string
+	Species  string
+	Item     string
+	Ability  string
+	Level    int
+	Nature   string
+	EVs      map[string]int
+	IVs      map[string]int
+	Moves    []string
+}
+
+func partyExport(cfg *config, format, path string) error {
+	if !strings.EqualFold(format, "showdown") {
+		return fmt.Errorf("Unknown export format '%s', expected showdown", format)
+	}
+	records := cfg.partyRecords()
+	if len(records) == 0 {
+		fmt.Printf("Your party is empty, there is nothing to export\n\n")
+		return nil
+	}
+
+	sets := make([]showdownSet, 0, len(records))
+	for _, record := range records {
+		sets = append(sets, showdownSetOf(record, cfg.caughtPokemon[record.Species]))
+	}
+	team := formatShowdown(sets)
+
+	if path == "" {
+		fmt.Print(team)
+		return nil
+	}
+	path = expandHome(path)
+	if err := os.WriteFile(path, []byte(team), 0o644); err != nil {
+		return fmt.Errorf("Error writing %s: %w", path, err)
+	}
+	fmt.Printf("Exported %d party Pokemon to %s\n\n", len(sets), path)
+	return nil
+}
+
+func partyImport(cfg *config, format, path string) error {
+	if !strings.EqualFold(format, "showdown") {
+		return fmt.Errorf("Unknown import format '%s', expected showdown", format)
+	}
+	path = expandHome(path)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+	sets, err := parseShowdown(string(raw))
+	if err != nil {
+		return fmt.Errorf("Error parsing %s: %w", path, err)
+	}
+	if len(sets) == 0 {
+		return fmt.Errorf("No Showdown sets found in %s", path)
+	}
+	if free := maxPartySize - len(cfg.party); len(sets) > free {
+		return fmt.Errorf("The team has %d Pokemon but your party only has room for %d", len(sets), free)
+	}
+
+	// Validate the whole team before touching the Pokedex so a bad file changes nothing
+	species := make([]pokeapi.Pokemon, len(sets))
+	var problems []string
+	for i, set := range sets {
+		pokemon, err := cfg.pokeClient.GetPokemonInfo(set.Species)
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			problems = append(problems, fmt.Sprintf("unknown Pokemon '%s'", set.Species))
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("Error fetching Pokemon '%s': %w", set.Species, err)
+		}
+		species[i] = pokemon
+		setProblems, err := cfg.validateShowdownSet(set, pokemon)
+		if err != nil {
+			return err
+		}
+		for _, problem := range setProblems {
+			problems = append(problems, fmt.Sprintf("%s: %s", set.Species, problem))
+		}
+		for _, other := range sets[:i] {
+			if set.Nickname != "" && strings.EqualFold(set.Nickname, other.Nickname) {
+				problems = append(problems, fmt.Sprintf("%s: nickname '%s' is used twice", set.Species, set.Nickname))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("Nothing imported:\n- %s", strings.Join(problems, "\n- "))
+	}
+
+	for i, set := range sets {
+		cfg.recordCatch(species[i])
+		record := &cfg.catches[len(cfg.catches)-1]
+		record.Location = "import:" + filepath.Base(path)
+		record.Nickname = set.Nickname
+		record.Item = set.Item
+		record.Ability = set.Ability
+		record.Level = set.Level
+		record.Nature = set.Nature
+		record.EVs = set.EVs
+		record.Moves = set.Moves
+		for _, stat := range statNames {
+			record.IVs[stat] = maxIV
+			if iv, ok := set.IVs[stat]; ok {
+				record.IVs[stat] = iv
+			}
+		}
+		cfg.party = append(cfg.party, record.ID)
+		cfg.markSeen(species[i].Species.Name)
+	}
+	if err := cfg.save(); err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d Pokemon from %s into your party\n\n", len(sets), path)
+	return printParty(cfg)
+}
+
+// Checks a parsed set against the species it was validated as, returning one
+// message per problem. Failing to reach PokeAPI is an error, not a problem
+// with the set.
+func (cfg *config) validateShowdownSet(set showdownSet, pokemon pokeapi.Pokemon) ([]string, error) {
+	var problems []string
+	if err := cfg.validateNickname(set.Nickname, 0); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if set.Level < 1 || set.Level > showdownLevel {
+		problems = append(problems, fmt.Sprintf("level %d is out of range (1-%d)", set.Level, showdownLevel))
+	}
+	if !slices.Contains(natures, set.Nature) {
+		problems = append(problems, fmt.Sprintf("unknown nature '%s'", set.Nature))
+	}
+	total := 0
+	for stat, ev := range set.EVs {
+		total += ev
+		if ev < 0 || ev > maxEV {
+			problems = append(problems, fmt.Sprintf("%s EVs %d are out of range (0-%d)", stat, ev, maxEV))
+		}
+	}
+	if total > maxTotalEVs {
+		problems = append(problems, fmt.Sprintf("%d total EVs is more than %d", total, maxTotalEVs))
+	}
+	for stat, iv := range set.IVs {
+		if iv < 0 || iv > maxIV {
+			problems = append(problems, fmt.Sprintf("%s IVs %d are out of range (0-%d)", stat, iv, maxIV))
+		}
+	}
+
+	for _, move := range set.Moves {
+		_, err := cfg.pokeClient.GetMove(context.Background(), move)
+		if errors.Is(err, pokeapi.ErrNotFound) {
+			problems = append(problems, fmt.Sprintf("unknown move '%s'", move))
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Error fetching move '%s': %w", move, err)
+		}
+		learnable := slices.ContainsFunc(pokemon.Moves, func(m pokeapi.PokemonMove) bool {
+			return m.Move.Name == move
+		})
+		if !learnable {
+			problems = append(problems, fmt.Sprintf("can't learn '%s'", move))
+		}
+	}
+	return problems, nil
+}
+
+// Records caught in the wild have no ability or moves of their own, so the
+// species' first regular ability stands in for the former
+func showdownSetOf(record catchRecord, pokemon pokeapi.Pokemon) showdownSet {
+	ability := record.Ability
+	if ability == "" {
+		for _, a := range pokemon.Abilities {
+			if !a.IsHidden {
+				ability = a.Ability.Name
+				break
+			}
+		}
+	}
+	return showdownSet{
+		Nickname: record.Nickname,
+		Species:  record.Species,
+		Item:     record.Item,
+		Ability:  ability,
+		Level:    record.Level,
+		Nature:   record.Nature,
+		EVs:      record.EVs,
+		IVs:      record.IVs,
+		Moves:    record.Moves,
+	}
+}
+
+func formatShowdown(sets []showdownSet) string {
+	var sb strings.Builder
+	for _, set := range sets {
+		if set.Nickname != "" {
+			fmt.Fprintf(&sb, "%s (%s)", set.Nickname, showdownName(set.Species, "-"))
+		} else {
+			sb.WriteString(showdownName(set.Species, "-"))
+		}
+		if set.Item != "" {
+			fmt.Fprintf(&sb, " @ %s", showdownName(set.Item, " "))
+		}
+		sb.WriteString("\n")
+		if set.Ability != "" {
+			fmt.Fprintf(&sb, "Ability: %s\n", showdownName(set.Ability, " "))
+		}
+		if set.Level != showdownLevel {
+			fmt.Fprintf(&sb, "Level: %d\n", set.Level)
+		}
+		if evs := showdownSpread(set.EVs, 0); evs != "" {
+			fmt.Fprintf(&sb, "EVs: %s\n", evs)
+		}
+		if set.Nature != "" {
+			fmt.Fprintf(&sb, "%s Nature\n", showdownName(set.Nature, " "))
+		}
+		if ivs := showdownSpread(set.IVs, maxIV); ivs != "" {
+			fmt.Fprintf(&sb, "IVs: %s\n", ivs)
+		}
+		for _, move := range set.Moves {
+			fmt.Fprintf(&sb, "- %s\n", showdownName(move, " "))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Formats "252 Atk / 4 SpD", leaving out stats at their default value
+func showdownSpread(values map[string]int, defaultValue int) string {
+	var parts []string
+	for _, stat := range statNames {
+		if value, ok := values[stat]; ok && value != defaultValue {
+			parts = append(parts, fmt.Sprintf("%d %s", value, showdownStats[stat]))
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+func parseShowdown(text string) ([]showdownSet, error) {
+	var sets []showdownSet
+	var current *showdownSet
+	for n, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sets = append(sets, parseShowdownHeader(line))
+			current = &sets[len(sets)-1]
+			continue
+		}
+
+		var err error
+		switch {
+		case strings.HasPrefix(line, "- "):
+			current.Moves = append(current.Moves, pokeapiName(strings.TrimPrefix(line, "- ")))
+		case strings.HasPrefix(line, "Ability:"):
+			current.Ability = pokeapiName(strings.TrimPrefix(line, "Ability:"))
+		case strings.HasPrefix(line, "Level:"):
+			current.Level, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "Level:")))
+		case strings.HasPrefix(line, "EVs:"):
+			current.EVs, err = parseShowdownSpread(strings.TrimPrefix(line, "EVs:"))
+		case strings.HasPrefix(line, "IVs:"):
+			current.IVs, err = parseShowdownSpread(strings.TrimPrefix(line, "IVs:"))
+		case strings.HasSuffix(line, " Nature"):
+			current.Nature = pokeapiName(strings.TrimSuffix(line, " Nature"))
+		}
+		// Anything else (Shiny, Tera Type, Happiness...) isn't tracked here
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+	return sets, nil
+}
+
+// Parses "Nickname (Species) (M) @ Item", where everything but the species is optional
+func parseShowdownHeader(line string) showdownSet {
+	set := showdownSet{Level: showdownLevel, Nature: showdownNature}
+	if name, item, ok := strings.Cut(line, " @ "); ok {
+		line = name
+		set.Item = pokeapiName(item)
+	}
+	line = strings.TrimSuffix(strings.TrimSuffix(line, " (M)"), " (F)")
+	if open := strings.LastIndex(line, " ("); open != -1 && strings.HasSuffix(line, ")") {
+		set.Nickname = strings.TrimSpace(line[:open])
+		line = line[open+2 : len(line)-1]
+	}
+	set.Species = pokeapiName(line)
+	return set
+}
+
+func parseShowdownSpread(spread string) (map[string]int, error) {
+	values := make(map[string]int)
+	for _, part := range strings.Split(spread, "/") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid stat spread '%s'", strings.TrimSpace(part))
+		}
+		value, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid stat value '%s'", fields[0])
+		}
+		stat := ""
+		for name, abbreviation := range showdownStats {
+			if strings.EqualFold(fields[1], abbreviation) {
+				stat = name
+			}
+		}
+		if stat == "" {
+			return nil, fmt.Errorf("unknown stat '%s'", fields[1])
+		}
+		values[stat] = value
+	}
+	return values, nil
+}
+
+// "Mr. Mime" -> "mr-mime", "King's Shield" -> "kings-shield"
+func pokeapiName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "-")
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, name)
+	return strings.Trim(strings.ReplaceAll(name, "--", "-"), "-")
+}
+
+// "volt-tackle" -> "Volt Tackle". Species keep their hyphens ("Rotom-Wash");
+// either way pokeapiName turns the result back into the PokeAPI name
+func showdownName(name, separator string) string {
+	words := strings.Split(name, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	return strings.Join(words, separator)
+}
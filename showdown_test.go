@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const showdownTeam = `Sparky (Pikachu) (M) @ Light Ball
+Ability: Static
+Level: 50
+Shiny: Yes
+EVs: 252 Atk / 4 SpD / 252 Spe
+Jolly Nature
+IVs: 0 SpA
+- Volt Tackle
+- Iron Tail
+
+Mr. Mime
+Ability: Filter
+- Psychic
+`
+
+func TestParseShowdown(t *testing.T) {
+	sets, err := parseShowdown(showdownTeam)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []showdownSet{
+		{
+			Nickname: "Sparky",
+			Species:  "pikachu",
+			Item:     "light-ball",
+			Ability:  "static",
+			Level:    50,
+			Nature:   "jolly",
+			EVs:      map[string]int{"attack": 252, "special-defense": 4, "speed": 252},
+			IVs:      map[string]int{"special-attack": 0},
+			Moves:    []string{"volt-tackle", "iron-tail"},
+		},
+		{
+			Species: "mr-mime",
+			Ability: "filter",
+			Level:   showdownLevel,
+			Nature:  showdownNature,
+			Moves:   []string{"psychic"},
+		},
+	}
+	if !reflect.DeepEqual(sets, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, sets)
+	}
+}
+
+func TestParseShowdownErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		team string
+	}{
+		{name: "unknown stat", team: "Pikachu\nEVs: 252 Luck\n"},
+		{name: "bad value", team: "Pikachu\nIVs: lots Atk\n"},
+		{name: "bad level", team: "Pikachu\nLevel: fifty\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parseShowdown(test.team); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestFormatShowdownRoundTrip(t *testing.T) {
+	sets, err := parseShowdown(showdownTeam)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reparsed, err := parseShowdown(formatShowdown(sets))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(sets, reparsed) {
+		t.Errorf("Expected %+v, got %+v", sets, reparsed)
+	}
+}
+
+func TestPartyImportShowdown(t *testing.T) {
+	pikachu := pokeapi.Pokemon{
+		ID:   25,
+		Name: "pikachu",
+		Moves: []pokeapi.PokemonMove{
+			{Move: pokeapi.NamedAPIResource{Name: "volt-tackle"}},
+			{Move: pokeapi.NamedAPIResource{Name: "iron-tail"}},
+		},
+	}
+	client := &mockClient{
+		getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+			if pokemonName == "raichu" {
+				return pokeapi.Pokemon{}, errors.New("Received status: 500 Internal Server Error")
+			}
+			if pokemonName != "pikachu" {
+				return pokeapi.Pokemon{}, pokeapi.ErrNotFound
+			}
+			return pikachu, nil
+		},
+		getMoveFunc: func(name string) (pokeapi.Move, error) {
+			if name == "splash-dance" {
+				return pokeapi.Move{}, pokeapi.ErrNotFound
+			}
+			if name == "thunder" {
+				return pokeapi.Move{}, errors.New("Received status: 500 Internal Server Error")
+			}
+			return pokeapi.Move{Name: name}, nil
+		},
+	}
+
+	tests := []struct {
+		name          string
+		team          string
+		expectedError bool
+		// When set, the error must mention it
+		errorText string
+	}{
+		{name: "valid", team: "Sparky (Pikachu) @ Light Ball\nLevel: 50\nJolly Nature\n- Volt Tackle\n- Iron Tail\n"},
+		{name: "unknown species", team: "Missingno\n- Volt Tackle\n", expectedError: true, errorText: "unknown Pokemon 'missingno'"},
+		{name: "unknown move", team: "Pikachu\n- Splash Dance\n", expectedError: true, errorText: "unknown move 'splash-dance'"},
+		{name: "species fetch failure", team: "Raichu\n", expectedError: true, errorText: "Error fetching Pokemon 'raichu'"},
+		{name: "move fetch failure", team: "Pikachu\n- Thunder\n", expectedError: true, errorText: "Error fetching move 'thunder'"},
+		{name: "unlearnable move", team: "Pikachu\n- Psychic\n", expectedError: true},
+		{name: "too many EVs", team: "Pikachu\nEVs: 252 HP / 252 Atk / 252 Spe\n", expectedError: true},
+		{name: "duplicate nickname", team: "Sparky (Pikachu)\n\nSparky (Pikachu)\n", expectedError: true},
+		{name: "nickname with spaces", team: "Big Sparky (Pikachu)\n", expectedError: true},
+		{name: "party too small", team: "Pikachu\n\nPikachu\n\nPikachu\n\nPikachu\n\nPikachu\n\nPikachu\n\nPikachu\n", expectedError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "team.txt")
+			if err := os.WriteFile(path, []byte(test.team), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cfg := &config{pokeClient: client, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+
+			err := commandParty(cfg, "import", "showdown", path)
+			if test.expectedError {
+				if err == nil {
+					t.Error("Expected an error")
+				} else if !strings.Contains(err.Error(), test.errorText) {
+					t.Errorf("Expected the error to mention %q, got %v", test.errorText, err)
+				}
+				if len(cfg.catches) != 0 || len(cfg.party) != 0 {
+					t.Errorf("Expected nothing imported, got %d catches", len(cfg.catches))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(cfg.party) != 1 {
+				t.Fatalf("Expected the imported Pokemon in the party, got %v", cfg.party)
+			}
+			record := cfg.catches[0]
+			if record.Nickname != "Sparky" || record.Item != "light-ball" || record.Level != 50 || record.Nature != "jolly" {
+				t.Errorf("Unexpected record %+v", record)
+			}
+			if record.IVs["speed"] != maxIV {
+				t.Errorf("Expected unspecified IVs to default to %d, got %d", maxIV, record.IVs["speed"])
+			}
+		})
+	}
+}
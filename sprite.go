@@ -0,0 +1,112 @@
+package main
+
+// Draws sprites in the terminal. With color, each character cell shows two
+// pixels using the upper half block: foreground is the top pixel, background
+// the bottom one. Without color, pixels become ASCII shading.
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+)
+
+const asciiRamp = " .:-=+*#%@"
+
+func decodeSprite(data []byte) (image.Image, error) {
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("Error decoding sprite: %w", err)
+	}
+	return img, nil
+}
+
+func renderSprite(img image.Image, useColor bool) string {
+	bounds := opaqueBounds(img)
+	if bounds.Empty() {
+		return ""
+	}
+	if useColor {
+		return renderHalfBlocks(img, bounds)
+	}
+	return renderASCII(img, bounds)
+}
+
+func renderHalfBlocks(img image.Image, bounds image.Rectangle) string {
+	var sb strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top, topVisible := pixel(img, x, y)
+			bottom, bottomVisible := color.RGBA{}, false
+			if y+1 < bounds.Max.Y {
+				bottom, bottomVisible = pixel(img, x, y+1)
+			}
+
+			switch {
+			case topVisible && bottomVisible:
+				fmt.Fprintf(&sb, "\x1b[38;2;%d;%d;%dm\x1b[48;2;%d;%d;%dm▀", top.R, top.G, top.B, bottom.R, bottom.G, bottom.B)
+			case topVisible:
+				fmt.Fprintf(&sb, "\x1b[0m\x1b[38;2;%d;%d;%dm▀", top.R, top.G, top.B)
+			case bottomVisible:
+				fmt.Fprintf(&sb, "\x1b[0m\x1b[38;2;%d;%d;%dm▄", bottom.R, bottom.G, bottom.B)
+			default:
+				sb.WriteString("\x1b[0m ")
+			}
+		}
+		sb.WriteString("\x1b[0m\n")
+	}
+	return sb.String()
+}
+
+// Terminal cells are about twice as tall as wide, so each character covers
+// two rows of pixels, shaded by the darker of the two
+func renderASCII(img image.Image, bounds image.Rectangle) string {
+	var sb strings.Builder
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		var line strings.Builder
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			density := shade(img, x, y)
+			if y+1 < bounds.Max.Y {
+				density = max(density, shade(img, x, y+1))
+			}
+			line.WriteByte(asciiRamp[density])
+		}
+		sb.WriteString(strings.TrimRight(line.String(), " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func shade(img image.Image, x, y int) int {
+	c, visible := pixel(img, x, y)
+	if !visible {
+		return 0
+	}
+	luminance := (299*int(c.R) + 587*int(c.G) + 114*int(c.B)) / 1000
+	return 1 + (255-luminance)*(len(asciiRamp)-2)/255
+}
+
+func pixel(img image.Image, x, y int) (color.RGBA, bool) {
+	r, g, b, a := img.At(x, y).RGBA()
+	if a < 0x8000 {
+		return color.RGBA{}, false
+	}
+	// Undo alpha premultiplication so semi-transparent edges keep their hue
+	return color.RGBA{R: uint8(r * 0xff / a), G: uint8(g * 0xff / a), B: uint8(b * 0xff / a), A: 0xff}, true
+}
+
+// Sprites sit in a mostly transparent canvas; trim it to what's visible
+func opaqueBounds(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	visible := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if _, ok := pixel(img, x, y); ok {
+				visible = visible.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return visible
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestRenderSprite(t *testing.T) {
+	// 4x4 canvas with a 2x2 opaque square in the middle
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 1; y <= 2; y++ {
+		for x := 1; x <= 2; x++ {
+			img.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+
+	tests := []struct {
+		name     string
+		useColor bool
+		check    func(t *testing.T, output string)
+	}{
+		{
+			name:     "ascii",
+			useColor: false,
+			check: func(t *testing.T, output string) {
+				if strings.Contains(output, "\x1b[") {
+					t.Errorf("Expected no escape codes, got %q", output)
+				}
+				lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+				if len(lines) != 1 || len(lines[0]) != 2 {
+					t.Errorf("Expected one line of 2 characters after cropping, got %q", output)
+				}
+			},
+		},
+		{
+			name:     "color",
+			useColor: true,
+			check: func(t *testing.T, output string) {
+				if !strings.Contains(output, "\x1b[38;2;255;0;0m\x1b[48;2;255;0;0m▀") {
+					t.Errorf("Expected red half blocks, got %q", output)
+				}
+				if strings.Count(output, "▀") != 2 {
+					t.Errorf("Expected 2 cells after cropping, got %q", output)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.check(t, renderSprite(img, test.useColor))
+		})
+	}
+}
+
+func TestRenderEmptySprite(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	if output := renderSprite(img, true); output != "" {
+		t.Errorf("Expected nothing for a transparent sprite, got %q", output)
+	}
+}
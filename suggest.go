@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const maxSuggestions = 3
+
+// Ranks names by how likely they are what the user meant to type:
+// prefix matches first, then anything within a few typos
+func suggestNames(query string, names []string, limit int) []string {
+	type candidate struct {
+		name     string
+		prefix   bool
+		distance int
+	}
+
+	maxDistance := max(1, len([]rune(query))/3)
+	var candidates []candidate
+	for _, name := range names {
+		if name == query {
+			continue
+		}
+		prefix := len(query) >= 3 && strings.HasPrefix(name, query)
+		distance := levenshtein(query, name)
+		if prefix || distance <= maxDistance {
+			candidates = append(candidates, candidate{name: name, prefix: prefix, distance: distance})
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.prefix != b.prefix {
+			return a.prefix
+		}
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.name < b.name
+	})
+
+	suggestions := make([]string, 0, limit)
+	for _, c := range candidates {
+		if len(suggestions) == limit {
+			break
+		}
+		suggestions = append(suggestions, c.name)
+	}
+	return suggestions
+}
+
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func (cfg *config) pokemonSuggestions(name string) []string {
+	return cfg.resourceSuggestions(pokeapi.KindPokemon, name)
+}
+
+func (cfg *config) resourceSuggestions(kind pokeapi.ResourceKind, name string) []string {
+	names, err := cfg.pokeClient.GetResourceNames(context.Background(), kind)
+	if err != nil {
+		return nil
+	}
+	return suggestNames(name, names, maxSuggestions)
+}
+
+func didYouMean(suggestions []string) string {
+	if len(suggestions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" Did you mean: %s?", strings.Join(suggestions, ", "))
+}
@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "pikachu", b: "pikachu", expected: 0},
+		{a: "pikchu", b: "pikachu", expected: 1},
+		{a: "bulbasuar", b: "bulbasaur", expected: 2},
+		{a: "", b: "mew", expected: 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+"/"+test.b, func(t *testing.T) {
+			if actual := levenshtein(test.a, test.b); actual != test.expected {
+				t.Errorf("Expected distance %d, got %d", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSuggestNames(t *testing.T) {
+	names := []string{"pikachu", "raichu", "charmander", "charmeleon", "charizard", "mew", "mewtwo"}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{
+			name:     "single typo",
+			query:    "pikchu",
+			expected: []string{"pikachu"},
+		},
+		{
+			name:     "prefix",
+			query:    "charm",
+			expected: []string{"charmander", "charmeleon"},
+		},
+		{
+			name:     "nothing close",
+			query:    "snorlax",
+			expected: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := suggestNames(test.query, names, maxSuggestions)
+			if len(actual) != len(test.expected) {
+				t.Fatalf("Expected %v, got %v", test.expected, actual)
+			}
+			for i := range actual {
+				if actual[i] != test.expected[i] {
+					t.Errorf("Expected %v, got %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
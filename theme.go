@@ -0,0 +1,277 @@
+package main
+
+// A small rendering layer: every colored piece of output goes through the
+// renderer, which knows the active theme and whether color is allowed at all
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+type theme struct {
+	name     string
+	types    map[string]string
+	success  string
+	failure  string
+	errorMsg string
+	heading  string
+	statLow  string
+	statMid  string
+	statHigh string
+	statTop  string
+}
+
+// Values are SGR parameters; 38;5;N picks from the 256-color palette
+var themes = map[string]theme{
+	"default": {
+		name: "default",
+		types: map[string]string{
+			"normal": "38;5;250", "fire": "38;5;196", "water": "38;5;33",
+			"electric": "38;5;220", "grass": "38;5;40", "ice": "38;5;117",
+			"fighting": "38;5;124", "poison": "38;5;128", "ground": "38;5;178",
+			"flying": "38;5;147", "psychic": "38;5;205", "bug": "38;5;106",
+			"rock": "38;5;136", "ghost": "38;5;61", "dragon": "38;5;57",
+			"dark": "38;5;95", "steel": "38;5;145", "fairy": "38;5;218",
+		},
+		success:  "1;32",
+		failure:  "33",
+		errorMsg: "1;31",
+		heading:  "1",
+		statLow:  "31",
+		statMid:  "33",
+		statHigh: "32",
+		statTop:  "36",
+	},
+	"pastel": {
+		name: "pastel",
+		types: map[string]string{
+			"normal": "38;5;188", "fire": "38;5;210", "water": "38;5;111",
+			"electric": "38;5;229", "grass": "38;5;151", "ice": "38;5;195",
+			"fighting": "38;5;174", "poison": "38;5;183", "ground": "38;5;223",
+			"flying": "38;5;189", "psychic": "38;5;218", "bug": "38;5;193",
+			"rock": "38;5;187", "ghost": "38;5;146", "dragon": "38;5;147",
+			"dark": "38;5;181", "steel": "38;5;252", "fairy": "38;5;225",
+		},
+		success:  "38;5;151",
+		failure:  "38;5;223",
+		errorMsg: "38;5;210",
+		heading:  "1;38;5;189",
+		statLow:  "38;5;210",
+		statMid:  "38;5;229",
+		statHigh: "38;5;151",
+		statTop:  "38;5;159",
+	},
+	// Bold/dim only, for terminals with poor color support
+	"mono": {
+		name:     "mono",
+		types:    map[string]string{},
+		success:  "1",
+		failure:  "2",
+		errorMsg: "1",
+		heading:  "1;4",
+		statLow:  "2",
+		statMid:  "",
+		statHigh: "1",
+		statTop:  "1",
+	},
+}
+
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+type renderer struct {
+	color bool
+	theme theme
+}
+
+func newRenderer(themeName string, useColor bool) (*renderer, error) {
+	t, ok := themes[themeName]
+	if !ok {
+		return nil, fmt.Errorf("Unknown theme '%s', expected one of: %s", themeName, strings.Join(themeNames(), ", "))
+	}
+	return &renderer{color: useColor, theme: t}, nil
+}
+
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Color is off when NO_COLOR is set (https://no-color.org), for dumb
+// terminals, and when output isn't a terminal
+func colorEnabled() bool {
+	if _, set := os.LookupEnv("NO_COLOR"); set {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// A nil renderer (as in tests) prints plain text
+func (r *renderer) enabled() bool {
+	return r != nil && r.color
+}
+
+func (r *renderer) paint(code, text string) string {
+	if !r.enabled() || code == "" {
+		return text
+	}
+	return "\x1b[" + code + "m" + text + "\x1b[0m"
+}
+
+func (r *renderer) typeName(name string) string {
+	if !r.enabled() {
+		return name
+	}
+	return r.paint(r.theme.types[name], name)
+}
+
+func (r *renderer) typeNames(names []string) string {
+	painted := make([]string, 0, len(names))
+	for _, name := range names {
+		painted = append(painted, r.typeName(name))
+	}
+	return strings.Join(painted, "/")
+}
+
+func (r *renderer) stat(value int) string {
+	if !r.enabled() {
+		return fmt.Sprint(value)
+	}
+	return r.paint(r.statCode(value), fmt.Sprint(value))
+}
+
+func (r *renderer) statCode(value int) string {
+	switch {
+	case value < 60:
+		return r.theme.statLow
+	case value < 90:
+		return r.theme.statMid
+	case value < 120:
+		return r.theme.statHigh
+	}
+	return r.theme.statTop
+}
+
+// Proportional bar, painted like the stat value it represents
+func (r *renderer) bar(value, maxValue, width int) string {
+	filled := min(width, max(0, value*width/maxValue))
+	if value > 0 && filled == 0 {
+		filled = 1
+	}
+	if !r.enabled() {
+		return strings.Repeat("#", filled) + strings.Repeat(".", width-filled)
+	}
+	return r.paint(r.statCode(value), strings.Repeat("█", filled)) + strings.Repeat("░", width-filled)
+}
+
+func (r *renderer) success(text string) string {
+	if !r.enabled() {
+		return text
+	}
+	return r.paint(r.theme.success, text)
+}
+
+func (r *renderer) failure(text string) string {
+	if !r.enabled() {
+		return text
+	}
+	return r.paint(r.theme.failure, text)
+}
+
+func (r *renderer) errorText(text string) string {
+	if !r.enabled() {
+		return text
+	}
+	return r.paint(r.theme.errorMsg, text)
+}
+
+func (r *renderer) heading(text string) string {
+	if !r.enabled() {
+		return text
+	}
+	return r.paint(r.theme.heading, text)
+}
+
+func visibleWidth(text string) int {
+	return utf8.RuneCountInString(ansiPattern.ReplaceAllString(text, ""))
+}
+
+func printTable(rows [][]string, rightAligned ...int) {
+	fmt.Print(formatTable(rows, rightAligned...))
+}
+
+// Lays out rows in aligned columns. Unlike text/tabwriter it measures cells
+// without their color codes, so painted cells still line up.
+func formatTable(rows [][]string, rightAligned ...int) string {
+	widths := map[int]int{}
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], visibleWidth(cell))
+		}
+	}
+
+	var table strings.Builder
+	for _, row := range rows {
+		var line strings.Builder
+		for i, cell := range row {
+			padding := strings.Repeat(" ", widths[i]-visibleWidth(cell))
+			isLast := i == len(row)-1
+			switch {
+			case slices.Contains(rightAligned, i):
+				line.WriteString(padding + cell)
+			case isLast:
+				line.WriteString(cell)
+			default:
+				line.WriteString(cell + padding)
+			}
+			if !isLast {
+				line.WriteString("  ")
+			}
+		}
+		table.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+	}
+	return table.String()
+}
+
+func headings(cfg *config, cells []string) []string {
+	painted := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		painted = append(painted, cfg.out.heading(cell))
+	}
+	return painted
+}
+
+func commandTheme(cfg *config, args ...string) error {
+	name := firstArg(args)
+	if name == "" {
+		current := cfg.settings.get("theme")
+		fmt.Printf("Available themes:\n")
+		for _, themeName := range themeNames() {
+			marker := " "
+			if themeName == current {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, themeName)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	// Same as 'config set theme', so the choice is saved for the next session
+	return configSet(cfg, "theme", name)
+}
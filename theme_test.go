@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRendererColor(t *testing.T) {
+	var plain *renderer
+	if got := plain.typeName("fire"); got != "fire" {
+		t.Errorf("Expected a nil renderer to print plain text, got %q", got)
+	}
+
+	off, err := newRenderer("default", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := off.success("caught!"); got != "caught!" {
+		t.Errorf("Expected no color when disabled, got %q", got)
+	}
+
+	on, err := newRenderer("default", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := on.typeName("fire"); got != "\x1b[38;5;196mfire\x1b[0m" {
+		t.Errorf("Expected fire to be painted red, got %q", got)
+	}
+	if got := on.typeName("unknown"); got != "unknown" {
+		t.Errorf("Expected types without a color to stay plain, got %q", got)
+	}
+
+	if _, err := newRenderer("neon", true); err == nil {
+		t.Error("Expected unknown theme to be rejected")
+	}
+}
+
+func TestFormatTableIgnoresColorCodes(t *testing.T) {
+	on, _ := newRenderer("default", true)
+	rows := [][]string{
+		{"NAME", "TYPES", "CAUGHT"},
+		{"charizard", on.typeNames([]string{"fire", "flying"}), "1"},
+		{"pikachu", on.typeName("electric"), "12"},
+	}
+
+	expected := "" +
+		"NAME       TYPES        CAUGHT\n" +
+		"charizard  " + rows[1][1] + "       1\n" +
+		"pikachu    " + rows[2][1] + "         12\n"
+	if got := formatTable(rows, 2); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestStatBar(t *testing.T) {
+	var plain *renderer
+	tests := []struct {
+		name     string
+		value    int
+		expected string
+	}{
+		{name: "empty", value: 0, expected: ".........."},
+		{name: "tiny stat still shows", value: 5, expected: "#........."},
+		{name: "half", value: 50, expected: "#####....."},
+		{name: "capped", value: 150, expected: "##########"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := plain.bar(test.value, 100, 10); got != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestCommandThemeIsSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := &config{settings: settings{path: path}}
+
+	if err := commandTheme(cfg, "pastel"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cfg.settings.get("theme") != "pastel" || cfg.out.theme.name != "pastel" {
+		t.Errorf("Expected the pastel theme to apply immediately, got %q", cfg.settings.get("theme"))
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(raw), `theme = "pastel"`) {
+		t.Errorf("Expected the theme to be saved, got:\n%s", raw)
+	}
+
+	if err := commandTheme(cfg, "neon"); err == nil {
+		t.Error("Expected an unknown theme to be rejected")
+	}
+	if cfg.settings.get("theme") != "pastel" {
+		t.Errorf("Expected an unknown theme to leave the setting alone, got %q", cfg.settings.get("theme"))
+	}
+}
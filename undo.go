@@ -0,0 +1,192 @@
+package main
+
+// Commands marked undoable in the registry record the catches as they were
+// before they ran, so 'undo' can put them back and 'redo' can reapply them.
+// The stacks live for the session only and belong to the active profile.
+// The catch log is history, not state, so undo never removes attempts from it.
+// Seen Pokemon and catch IDs only move forward, and party or box changes made
+// since are kept: undo only takes out catches that are gone and puts back the
+// ones that return.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	pokeapi "github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+const maxUndoDepth = 20
+
+type undoEntry struct {
+	// The command line that made the change, e.g. "release 3"
+	command string
+	// The encoded undoState on the other side of the change
+	state []byte
+}
+
+type undoStack struct {
+	undo []undoEntry
+	redo []undoEntry
+}
+
+// The part of the Pokedex that undo tracks: the catches, and where they were
+// in the party and boxes so returning catches go back to the same place
+type undoState struct {
+	CaughtPokemon map[string]pokeapi.Pokemon `json:"caught_pokemon"`
+	Catches       []catchRecord              `json:"catches"`
+	Party         []int                      `json:"party"`
+	Boxes         []pcBox                    `json:"boxes"`
+}
+
+// Encodes the state through JSON, which also makes it a deep copy that later
+// commands can't mutate
+func (cfg *config) encodeState() ([]byte, error) {
+	data, err := json.Marshal(undoState{
+		CaughtPokemon: cfg.caughtPokemon,
+		Catches:       cfg.catches,
+		Party:         cfg.party,
+		Boxes:         cfg.boxes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error encoding Pokedex state: %w", err)
+	}
+	return data, nil
+}
+
+func (cfg *config) restoreState(state []byte) error {
+	var data undoState
+	if err := json.Unmarshal(state, &data); err != nil {
+		return fmt.Errorf("Error decoding Pokedex state: %w", err)
+	}
+
+	cfg.caughtPokemon = data.CaughtPokemon
+	if cfg.caughtPokemon == nil {
+		cfg.caughtPokemon = make(map[string]pokeapi.Pokemon)
+	}
+	cfg.catches = data.Catches
+	kept := make(map[int]bool, len(cfg.catches))
+	for _, record := range cfg.catches {
+		kept[record.ID] = true
+	}
+
+	// Catches that are gone leave the party and boxes, the rest stay put
+	placed := make(map[int]bool)
+	gone := func(id int) bool {
+		if !kept[id] {
+			return true
+		}
+		placed[id] = true
+		return false
+	}
+	cfg.party = slices.DeleteFunc(cfg.party, gone)
+	for i := range cfg.boxes {
+		cfg.boxes[i].Members = slices.DeleteFunc(cfg.boxes[i].Members, gone)
+	}
+
+	// Catches that come back return to their old slot or box, if there's room.
+	// Boxes that are gone (as after clear) come back too, even empty ones.
+	for i, id := range data.Party {
+		if kept[id] && !placed[id] && len(cfg.party) < maxPartySize {
+			cfg.party = slices.Insert(cfg.party, min(i, len(cfg.party)), id)
+			placed[id] = true
+		}
+	}
+	for _, saved := range data.Boxes {
+		box, ok := cfg.findBox(saved.Name)
+		if !ok {
+			cfg.boxes = append(cfg.boxes, pcBox{Name: saved.Name})
+			box = &cfg.boxes[len(cfg.boxes)-1]
+		}
+		for _, id := range saved.Members {
+			if !kept[id] || placed[id] {
+				continue
+			}
+			if len(box.Members) < boxCapacity {
+				box.Members = append(box.Members, id)
+				placed[id] = true
+			}
+		}
+	}
+	return cfg.save()
+}
+
+// Runs a command from the registry, recording an undo step when an undoable
+// command actually changed something
+func runCommand(cfg *config, command cliCommand, args ...string) error {
+	if !command.undoable {
+		return command.callback(cfg, args...)
+	}
+
+	before, err := cfg.encodeState()
+	if err != nil {
+		return err
+	}
+	// A command can fail after changing something (say, when saving), which
+	// is still worth being able to undo
+	runErr := command.callback(cfg, args...)
+	after, err := cfg.encodeState()
+	if err != nil {
+		return errors.Join(runErr, err)
+	}
+	if !bytes.Equal(before, after) {
+		line := strings.TrimSpace(command.name + " " + strings.Join(args, " "))
+		cfg.history.undo = pushBounded(cfg.history.undo, undoEntry{command: line, state: before})
+		cfg.history.redo = nil
+	}
+	return runErr
+}
+
+func pushBounded(stack []undoEntry, entry undoEntry) []undoEntry {
+	stack = append(stack, entry)
+	if len(stack) > maxUndoDepth {
+		stack = stack[len(stack)-maxUndoDepth:]
+	}
+	return stack
+}
+
+func commandUndo(cfg *config, args ...string) error {
+	if len(cfg.history.undo) == 0 {
+		fmt.Printf("Nothing to undo\n\n")
+		return nil
+	}
+	command, err := cfg.swapState(&cfg.history.undo, &cfg.history.redo)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Undid '%s'\n\n", command)
+	return nil
+}
+
+func commandRedo(cfg *config, args ...string) error {
+	if len(cfg.history.redo) == 0 {
+		fmt.Printf("Nothing to redo\n\n")
+		return nil
+	}
+	command, err := cfg.swapState(&cfg.history.redo, &cfg.history.undo)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Redid '%s'\n\n", command)
+	return nil
+}
+
+// Pops the newest entry off from, restores its state and pushes the state it
+// replaced onto to, so the same step can be reversed again
+func (cfg *config) swapState(from, to *[]undoEntry) (string, error) {
+	entry := (*from)[len(*from)-1]
+	current, err := cfg.encodeState()
+	if err != nil {
+		return "", err
+	}
+	if err := cfg.restoreState(entry.state); err != nil {
+		return "", err
+	}
+
+	*from = (*from)[:len(*from)-1]
+	*to = pushBounded(*to, undoEntry{command: entry.command, state: current})
+	return entry.command, nil
+}
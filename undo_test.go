@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/CamilleOnoda/pokedexcli/internal/pokeapi"
+)
+
+func TestUndoRedo(t *testing.T) {
+	cfg := &config{pokeClient: &mockClient{}, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+	cfg.recordCatch(pokeapi.Pokemon{ID: 1, Name: "bulbasaur"})
+	cfg.party = []int{1, 2}
+	cfg.boxes = []pcBox{{Name: "Spare"}}
+
+	if err := runCommand(cfg, commands["rename"], "1", "Sparky"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := runCommand(cfg, commands["release"], "2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := runCommand(cfg, commands["clear"]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.catches) != 0 || len(cfg.caughtPokemon) != 0 || len(cfg.party) != 0 {
+		t.Fatalf("Expected clear to empty the Pokedex, got %d catches", len(cfg.catches))
+	}
+
+	if err := commandUndo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.catches) != 1 || cfg.catches[0].Nickname != "Sparky" || len(cfg.party) != 1 {
+		t.Fatalf("Expected undo to bring back the renamed pikachu, got %+v", cfg.catches)
+	}
+	if _, ok := cfg.findBox("Spare"); !ok {
+		t.Errorf("Expected undoing clear to bring back the empty box, got %+v", cfg.boxes)
+	}
+	if err := commandUndo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := cfg.findCatch("2"); !ok || len(cfg.party) != 2 {
+		t.Errorf("Expected undo to bring back the released bulbasaur, got %+v", cfg.catches)
+	}
+
+	if err := commandRedo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := cfg.findCatch("2"); ok {
+		t.Error("Expected redo to release bulbasaur again")
+	}
+
+	// A new change forgets whatever could still be redone
+	if err := runCommand(cfg, commands["rename"], "1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.history.redo) != 0 {
+		t.Errorf("Expected the redo stack to be cleared, got %d entries", len(cfg.history.redo))
+	}
+}
+
+func TestUndoIgnoresNoOps(t *testing.T) {
+	cfg := &config{pokeClient: &mockClient{}, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+
+	// Nothing to release and usage messages leave the Pokedex untouched
+	if err := runCommand(cfg, commands["release"], "7"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := runCommand(cfg, commands["rename"]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.history.undo) != 0 {
+		t.Errorf("Expected no undo steps, got %d", len(cfg.history.undo))
+	}
+	if err := commandUndo(cfg); err != nil {
+		t.Errorf("Expected undo with an empty stack to be harmless, got %v", err)
+	}
+}
+
+func TestUndoDepthIsBounded(t *testing.T) {
+	cfg := &config{pokeClient: &mockClient{}, caughtPokemon: make(map[string]pokeapi.Pokemon)}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 25, Name: "pikachu"})
+
+	for i := range maxUndoDepth + 5 {
+		if err := runCommand(cfg, commands["rename"], "1", fmt.Sprintf("Sparky%c", 'a'+i)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if len(cfg.history.undo) != maxUndoDepth {
+		t.Fatalf("Expected %d undo steps, got %d", maxUndoDepth, len(cfg.history.undo))
+	}
+	for range maxUndoDepth {
+		if err := commandUndo(cfg); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// The oldest five renames fell off the stack
+	if cfg.catches[0].Nickname != "Sparkye" {
+		t.Errorf("Expected the oldest kept state, got %s", cfg.catches[0].Nickname)
+	}
+}
+
+func TestUndoCatchKeepsLaterChanges(t *testing.T) {
+	baseExperience := 0
+	cfg := &config{
+		pokeClient: &mockClient{
+			getPokemonInfoFunc: func(pokemonName string) (pokeapi.Pokemon, error) {
+				return pokeapi.Pokemon{Name: pokemonName, BaseExperience: baseExperience}, nil
+			},
+		},
+		caughtPokemon: make(map[string]pokeapi.Pokemon),
+	}
+	cfg.recordCatch(pokeapi.Pokemon{ID: 1, Name: "bulbasaur"})
+
+	if err := runCommand(cfg, commands["catch"], "pikachu"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Party edits aren't undoable and must survive undoing the catch
+	for _, id := range []string{"1", "2"} {
+		if err := runCommand(cfg, commands["party"], "add", id); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if err := commandUndo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := cfg.findCatch("2"); ok {
+		t.Fatal("Expected undo to take back the caught pikachu")
+	}
+	if len(cfg.party) != 1 || cfg.party[0] != 1 {
+		t.Errorf("Expected bulbasaur to stay in the party, got %v", cfg.party)
+	}
+	if !cfg.seen["pikachu"] {
+		t.Error("Expected pikachu to stay seen")
+	}
+
+	if err := commandRedo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.party) != 2 || cfg.party[1] != 2 {
+		t.Errorf("Expected redo to put pikachu back in its party slot, got %v", cfg.party)
+	}
+	if err := commandUndo(cfg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Catch IDs are never handed out twice
+	if err := runCommand(cfg, commands["catch"], "eevee"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record, ok := cfg.findCatch("3"); !ok || record.Species != "eevee" {
+		t.Errorf("Expected eevee to get a new catch ID, got %+v", cfg.catches)
+	}
+
+	// Seeing a new species without catching it isn't an undo step
+	steps := len(cfg.history.undo)
+	baseExperience = 1000
+	if err := runCommand(cfg, commands["catch"], "mewtwo"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !cfg.seen["mewtwo"] || len(cfg.history.undo) != steps {
+		t.Errorf("Expected an escape to mark mewtwo seen without an undo step, got %d steps", len(cfg.history.undo))
+	}
+}